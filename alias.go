@@ -0,0 +1,88 @@
+package argsx
+
+import "strings"
+
+// Alias declares aliases of the canonical key, any alias in args fills the canonical key
+// and Fetch by alias returns the value of canonical key. Parsing reports a conflict error
+// by Err when the key and its aliases are specified with different values
+//
+//	Alias("port", "p", "listen-port")
+func (x *Argsx) Alias(canonical string, aliases ...string) {
+	x.mux.Lock()
+	defer x.mux.Unlock()
+
+	x.resolver.alias(canonical, aliases...)
+	x.reset()
+}
+
+// Alias declares aliases of the canonical key on the default instance
+func Alias(canonical string, aliases ...string) {
+	dx.Alias(canonical, aliases...)
+}
+
+// resolver resolves keys to canonical form by normalizers and aliases
+type resolver struct {
+	normalizers []Normalizer
+	aliases     map[string]string
+}
+
+// clone returns a copy of resolver can't be affected by later changes
+func (r resolver) clone() resolver {
+	aliases := make(map[string]string, len(r.aliases))
+	for alias, canonical := range r.aliases {
+		aliases[alias] = canonical
+	}
+	return resolver{
+		normalizers: append([]Normalizer(nil), r.normalizers...),
+		aliases:     aliases,
+	}
+}
+
+// alias declares aliases of the canonical key
+func (r *resolver) alias(canonical string, aliases ...string) {
+	if r.aliases == nil {
+		r.aliases = make(map[string]string)
+	}
+	canonical = strings.TrimLeft(canonical, "-")
+	for _, alias := range aliases {
+		r.aliases[strings.TrimLeft(alias, "-")] = canonical
+	}
+}
+
+// unalias removes the aliases of the canonical key, the aliases of other keys are kept
+func (r *resolver) unalias(canonical string, aliases ...string) {
+	canonical = strings.TrimLeft(canonical, "-")
+	for _, alias := range aliases {
+		alias = strings.TrimLeft(alias, "-")
+		if r.aliases[alias] == canonical {
+			delete(r.aliases, alias)
+		}
+	}
+}
+
+// normalize returns the normalized form of key
+func (r resolver) normalize(key string) string {
+	for _, normalizer := range r.normalizers {
+		key = normalizer(key)
+	}
+	return key
+}
+
+// target returns the canonical key declared for the alias key without normalizing
+func (r resolver) target(key string) (string, bool) {
+	key = r.normalize(key)
+	for alias, canonical := range r.aliases {
+		if r.normalize(alias) == key {
+			return canonical, true
+		}
+	}
+	return "", false
+}
+
+// canonical returns the normalized canonical key of key
+func (r resolver) canonical(key string) string {
+	if canonical, ok := r.target(key); ok {
+		return r.normalize(canonical)
+	}
+	return r.normalize(key)
+}
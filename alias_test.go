@@ -0,0 +1,75 @@
+package argsx
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlias(t *testing.T) {
+	x := NewWithArgs([]string{"app", "-p", "8080", "--v"})
+	x.Alias("port", "p", "listen-port")
+	x.Register(Flag{Name: "verbose", Aliases: []string{"v"}, Usage: "verbose output", Type: "bool"})
+	require.NoError(t, x.Err())
+	require.Equal(t, 8080, x.Fetch("port").MustInt())
+	require.Equal(t, 8080, x.Fetch("listen-port").MustInt())
+	require.Equal(t, "-p", x.Fetch("port").fullkey)
+	require.True(t, x.Fetch("verbose").MustBool())
+
+	x.SetArgs([]string{"app", "--port", "80", "--listen-port=80"})
+	require.NoError(t, x.Err())
+	require.Equal(t, 80, x.Fetch("p").MustInt())
+
+	x.SetArgs([]string{"app", "--port", "80", "--listen-port=8080"})
+	require.EqualError(t, x.Err(), "conflicting values for key: `--port`, `--port=80` and `--listen-port=8080`")
+
+	x.SetNormalizer(FoldCase)
+	x.SetArgs([]string{"app", "--Listen-Port=9090"})
+	require.Equal(t, 9090, x.Fetch("PORT").MustInt())
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, x.WriteHelp(buf))
+	require.Contains(t, buf.String(), "  -v, --verbose   verbose output\n")
+}
+
+func TestFlagAliases(t *testing.T) {
+	x := NewWithArgs([]string{"app", "-c", "app.yaml"})
+	x.Register(Flag{Name: "config", Aliases: []string{"c", "conf"}, Usage: "config file", Hint: HintFile})
+	require.Equal(t, "app.yaml", x.Fetch("config").MustString())
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, x.WriteCompletion(buf, "bash"))
+	require.Contains(t, buf.String(), "        -c|--conf|--config)\n            COMPREPLY=($(compgen -f -- \"$cur\"))\n")
+	require.Contains(t, buf.String(), "compgen -W '-c --conf --config'")
+
+	buf.Reset()
+	require.NoError(t, x.WriteCompletion(buf, "zsh"))
+	require.Contains(t, buf.String(), "'(-c --conf --config)-c[config file]:config:_files'")
+	require.Contains(t, buf.String(), "'(-c --conf --config)--config[config file]:config:_files'")
+
+	buf.Reset()
+	require.NoError(t, x.WriteCompletion(buf, "fish"))
+	require.Contains(t, buf.String(), "complete -c app -s c -l conf -l config -d 'config file' -r -F\n")
+
+	buf.Reset()
+	require.NoError(t, x.WriteMan(buf, ManHeader{}))
+	require.Contains(t, buf.String(), `\fB\-c, \-\-conf, \-\-config\fR \fIstring\fR`)
+
+	buf.Reset()
+	require.NoError(t, x.WriteMarkdown(buf, MarkdownHeader{}))
+	require.Contains(t, buf.String(), "| `-c`, `--conf`, `--config` | string |")
+
+	buf.Reset()
+	x.SetArgs([]string{"app", "__complete", "--con"})
+	require.True(t, x.HandleCompletion(buf))
+	require.Equal(t, "--conf\tconfig file\n--config\tconfig file\n", buf.String())
+
+	// registering again replaces the aliases
+	x.Register(Flag{Name: "config", Aliases: []string{"f"}})
+	x.SetArgs([]string{"app", "-c", "app.yaml"})
+	require.Equal(t, Value{}, x.Fetch("config"))
+	require.Equal(t, "app.yaml", x.Fetch("c").MustString())
+	x.SetArgs([]string{"app", "-f", "app.yaml"})
+	require.Equal(t, "app.yaml", x.Fetch("config").MustString())
+}
@@ -1,16 +1,29 @@
 package argsx
 
 import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"os"
 	"sync"
 	"sync/atomic"
 )
 
 type Argsx struct {
-	args   []string
-	values map[string]Value
-	done   uint32
-	mux    sync.Mutex
+	args        []string
+	flags       []Flag
+	resolver    resolver
+	listeners   []ChangeFunc
+	flagSets    []*flag.FlagSet
+	strict      bool
+	warn        io.Writer
+	stdin       io.Reader
+	stdinSecret []byte
+	prompted    map[string]string
+	warned      map[string]bool
+	snapshot    atomic.Pointer[Snapshot]
+	mux         sync.Mutex
 }
 
 // New returns arg parser with os.Args
@@ -20,31 +33,148 @@ func New() *Argsx {
 
 // NewWithArgs returns arg parser with custom args
 func NewWithArgs(args []string) *Argsx {
-	return &Argsx{
-		args:   args,
-		values: make(map[string]Value),
-	}
+	return &Argsx{args: args}
 }
 
 // Fetch get the args value by key
 func (x *Argsx) Fetch(key string) Value {
-	x.parseArgs()
-	return x.values[key]
+	return x.Snapshot().Fetch(key)
+}
+
+// Parse parses args immediately and returns the error of tokenization like unreadable
+// response files, conflicting aliases, missing required flags, invalid values of registered
+// flags and unknown flags in strict mode. Without Parse args are parsed lazily on the first
+// Fetch and the error is available by Err. Parse also sets the values to imported flag sets
+//
+//	if err := Parse(); err != nil {
+//		log.Fatal(err)
+//	}
+func (x *Argsx) Parse() error {
+	x.mux.Lock()
+	defer x.mux.Unlock()
+	s := x.parse()
+	s.err = errors.Join(s.err, s.applyFlagSets(x.flagSets))
+	x.store(s)
+	return s.err
+}
+
+// Err returns the error occurred in the last parsing, see Parse
+func (x *Argsx) Err() error {
+	return x.Snapshot().Err()
+}
+
+// SetStrict reports the keys not registered as unknown flag error when strict is true
+func (x *Argsx) SetStrict(strict bool) {
+	x.mux.Lock()
+	defer x.mux.Unlock()
+	x.strict = strict
+	x.reset()
+}
+
+// Snapshot returns the immutable result of the current args, args are parsed if not yet.
+// The snapshot is not affected by SetArgs or Reload, it's a consistent view for a request
+//
+//	s := Snapshot()
+//	s.Fetch("host") // always read from the same parsing with s.Fetch("port")
+func (x *Argsx) Snapshot() *Snapshot {
+	if s := x.snapshot.Load(); s != nil {
+		return s
+	}
+
+	x.mux.Lock()
+	defer x.mux.Unlock()
+	if s := x.snapshot.Load(); s != nil {
+		return s
+	}
+	s := x.parse()
+	x.store(s)
+	return s
 }
 
-// SetArgs replace the old args
+// SetArgs replace the old args, the next Fetch parses the new args
 func (x *Argsx) SetArgs(args []string) {
+	x.mux.Lock()
+	defer x.mux.Unlock()
 	x.args = args
-	atomic.StoreUint32(&x.done, 0)
+	x.warned = nil
+	x.reset()
+}
+
+// reset drops the current snapshot after config changed, the caller must hold x.mux
+func (x *Argsx) reset() {
+	x.snapshot.Store(nil)
+}
+
+// store swaps the current snapshot and writes the warnings of s not written
+// since args changed, the caller must hold x.mux
+func (x *Argsx) store(s *Snapshot) {
+	x.snapshot.Store(s)
+	w := x.warn
+	if w == nil {
+		w = os.Stderr
+	}
+	for _, warning := range s.warnings {
+		if x.warned[warning] {
+			continue
+		}
+		if x.warned == nil {
+			x.warned = make(map[string]bool)
+		}
+		x.warned[warning] = true
+		fmt.Fprintf(w, "warning: %s\n", warning)
+	}
+}
+
+// getArgs returns the current args
+func (x *Argsx) getArgs() []string {
+	x.mux.Lock()
+	defer x.mux.Unlock()
+	return x.args
 }
 
 var dx = New()
 
+// Default returns the default instance used by package level functions
+func Default() *Argsx {
+	return dx
+}
+
+// SetDefault replaces the default instance used by package level functions and returns the old one,
+// it's not safe to call concurrently with package level functions
+//
+//	old := SetDefault(NewWithArgs([]string{"app", "--port", "8080"}))
+//	defer SetDefault(old)
+func SetDefault(x *Argsx) *Argsx {
+	old := dx
+	dx = x
+	return old
+}
+
 // SetArgs replace old args
 func SetArgs(args []string) {
 	dx.SetArgs(args)
 }
 
+// Parse parses args of the default instance immediately and returns the error
+func Parse() error {
+	return dx.Parse()
+}
+
+// SetStrict reports the keys not registered as unknown flag error on the default instance
+func SetStrict(strict bool) {
+	dx.SetStrict(strict)
+}
+
+// Err returns the error occurred in the last parsing of the default instance
+func Err() error {
+	return dx.Err()
+}
+
+// Current returns the snapshot of the default instance
+func Current() *Snapshot {
+	return dx.Snapshot()
+}
+
 // Fetch get the args value by key
 //
 //	os.Args = []string{"--config", "~/config/file/path.yaml"}
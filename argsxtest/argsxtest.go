@@ -0,0 +1,123 @@
+// Package argsxtest provides helpers for testing command line tools built on argsx
+// without mutating os.Args or leaking the default instance between tests
+package argsxtest
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charliego3/argsx"
+)
+
+// Program is the program name of the args of fixtures
+const Program = "app"
+
+// update is namespaced to not conflict with the common -update flag of test packages
+var update = flag.Bool("argsxtest.update", false, "update golden files of argsxtest")
+
+// New returns an isolated parser of args prefixed with Program, warnings are written to t.Log
+//
+//	x := argsxtest.New(t, "--port", "8080")
+//	x.Fetch("port").MustInt() // 8080
+func New(t testing.TB, args ...string) *argsx.Argsx {
+	t.Helper()
+	x := argsx.NewWithArgs(append([]string{Program}, args...))
+	x.SetWarnWriter(logWriter{t})
+	return x
+}
+
+// SetDefault replaces the default instance of argsx by a parser of args and restores it when the test ends.
+// It can not be used in parallel tests
+//
+//	argsxtest.SetDefault(t, "--port", "8080")
+//	argsx.Fetch("port").MustInt() // 8080
+func SetDefault(t testing.TB, args ...string) *argsx.Argsx {
+	t.Helper()
+	x := New(t, args...)
+	old := argsx.SetDefault(x)
+	t.Cleanup(func() { argsx.SetDefault(old) })
+	return x
+}
+
+// Setenv sets the environment variables of key value pairs and restores them when the test ends
+//
+//	argsxtest.Setenv(t, "APP_PORT", "8080", "APP_HOST", "localhost")
+func Setenv(t testing.TB, kv ...string) {
+	t.Helper()
+	if len(kv)%2 != 0 {
+		t.Fatalf("argsxtest: odd number of key value pairs: %v", kv)
+	}
+	for i := 0; i < len(kv); i += 2 {
+		t.Setenv(kv[i], kv[i+1])
+	}
+}
+
+// File writes content to the file name in a temporary directory removed when the test ends, returns the path
+func File(t testing.TB, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("argsxtest: write file: %v", err)
+	}
+	return path
+}
+
+// FlagFile writes args one per line to a temporary flag file and returns the arg to load it
+//
+//	x := argsxtest.New(t, argsxtest.FlagFile(t, "--port", "8080"))
+func FlagFile(t testing.TB, args ...string) string {
+	t.Helper()
+	return "--flagfile=" + File(t, "flags", strings.Join(args, "\n")+"\n")
+}
+
+// Golden compares got with the golden file testdata/name.golden,
+// the file is rewritten with got when the test runs with -argsxtest.update
+//
+//	go test ./... -argsxtest.update
+func Golden(t testing.TB, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("argsxtest: update golden file: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("argsxtest: update golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("argsxtest: read golden file, run with -argsxtest.update to create it: %v", err)
+	}
+	if !bytes.Equal(want, got) {
+		t.Errorf("argsxtest: %s mismatch, run with -argsxtest.update to accept\n--- want\n%s\n--- got\n%s", path, want, got)
+	}
+}
+
+// Help compares the help output of x with the golden file testdata/name.golden
+//
+//	argsxtest.Help(t, x, "help")
+func Help(t testing.TB, x *argsx.Argsx, name string) {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	if err := x.WriteHelp(buf); err != nil {
+		t.Fatalf("argsxtest: write help: %v", err)
+	}
+	Golden(t, name, buf.Bytes())
+}
+
+// logWriter writes to the log of test
+type logWriter struct {
+	t testing.TB
+}
+
+func (w logWriter) Write(p []byte) (int, error) {
+	w.t.Log(strings.TrimRight(string(p), "\n"))
+	return len(p), nil
+}
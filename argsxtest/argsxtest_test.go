@@ -0,0 +1,45 @@
+package argsxtest
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/charliego3/argsx"
+	"github.com/stretchr/testify/require"
+)
+
+// the common -update flag of test packages must not conflict with argsxtest
+var _ = flag.Bool("update", false, "update golden files")
+
+func TestNew(t *testing.T) {
+	x := New(t, "--port", "8080", FlagFile(t, "--host", "localhost"))
+	require.Equal(t, 8080, x.Fetch("port").MustInt())
+	require.Equal(t, "localhost", x.Fetch("host").MustString())
+	require.Equal(t, Program, x.Name())
+}
+
+func TestSetDefault(t *testing.T) {
+	old := argsx.Default()
+	t.Run("override", func(t *testing.T) {
+		x := SetDefault(t, "--port", "8080")
+		require.Same(t, x, argsx.Default())
+		require.Equal(t, 8080, argsx.Fetch("port").MustInt())
+	})
+	require.Same(t, old, argsx.Default())
+}
+
+func TestSetenv(t *testing.T) {
+	Setenv(t, "ARGSXTEST_PORT", "9090")
+	x := New(t)
+	x.Register(argsx.Flag{Name: "port", Type: "int", Env: "ARGSXTEST_PORT"})
+	require.Equal(t, 9090, x.Fetch("port").MustInt())
+}
+
+func TestHelp(t *testing.T) {
+	x := New(t)
+	x.Register(
+		argsx.Flag{Name: "port", Type: "int", Usage: "listen port", Default: "8080"},
+		argsx.Flag{Name: "format", Usage: "output format", Choices: []string{"json", "yaml"}},
+	)
+	Help(t, x, "help")
+}
@@ -0,0 +1,111 @@
+package argsx
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// typeCheckers check the payload of registered flags by Flag.Type
+var typeCheckers = map[string]func(payload string) error{
+	"bool": func(payload string) error {
+		if len(payload) == 0 {
+			return nil
+		}
+		_, err := strconv.ParseBool(payload)
+		return err
+	},
+	"int": func(payload string) error {
+		_, err := strconv.Atoi(payload)
+		return err
+	},
+	"int8":     intChecker(8),
+	"int16":    intChecker(16),
+	"int32":    intChecker(32),
+	"int64":    intChecker(64),
+	"uint":     uintChecker(0),
+	"uint64":   uintChecker(64),
+	"float32":  floatChecker(32),
+	"float64":  floatChecker(64),
+	"duration": func(payload string) error { _, err := ParseDuration(payload); return err },
+}
+
+// intChecker returns a checker of integer with bit size
+func intChecker(bitSize int) func(string) error {
+	return func(payload string) error {
+		_, err := strconv.ParseInt(payload, 0, bitSize)
+		return err
+	}
+}
+
+// uintChecker returns a checker of unsigned integer with bit size
+func uintChecker(bitSize int) func(string) error {
+	return func(payload string) error {
+		_, err := strconv.ParseUint(payload, 0, bitSize)
+		return err
+	}
+}
+
+// floatChecker returns a checker of float with bit size
+func floatChecker(bitSize int) func(string) error {
+	return func(payload string) error {
+		_, err := strconv.ParseFloat(payload, bitSize)
+		return err
+	}
+}
+
+// check validates the values of snapshot against registered flags:
+// required flags must be specified, payloads must match Type, Choices and Validate,
+// and the keys not registered are rejected in strict mode
+func (s *Snapshot) check(strict bool) error {
+	var errs []error
+	known := map[string]bool{s.resolver.canonical(enableExperimental): true}
+	for _, flag := range s.flags {
+		ck := s.resolver.canonical(flag.Name)
+		known[ck] = true
+
+		value, ok := s.values[ck]
+		if !ok {
+			if flag.Required {
+				errs = append(errs, fmt.Errorf("required key not specified: `%s`", flag.dash()))
+			}
+			continue
+		}
+		if err := flag.check(value); err != nil {
+			errs = append(errs, fmt.Errorf("invalid value `%s` for key: `%s`: %w", value.Raw(), value.fullkey, err))
+		}
+	}
+
+	if strict {
+		var unknown []string
+		for key, value := range s.values {
+			if !known[key] {
+				unknown = append(unknown, value.fullkey)
+			}
+		}
+		sort.Strings(unknown)
+		for _, key := range unknown {
+			errs = append(errs, fmt.Errorf("unknown flag: `%s`", key))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// check validates value by Type, Choices and Validate of flag
+func (f Flag) check(value Value) error {
+	if checker, ok := typeCheckers[f.Type]; ok {
+		if err := checker(value.payload); err != nil {
+			return fmt.Errorf("not a valid %s", f.Type)
+		}
+	}
+	if len(f.Choices) > 0 {
+		if _, err := value.enum(f.Choices, false)(value.payload); err != nil {
+			return fmt.Errorf("must be one of %v", f.Choices)
+		}
+	}
+	if f.Validate != nil {
+		return f.Validate(value)
+	}
+	return nil
+}
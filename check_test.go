@@ -0,0 +1,69 @@
+package argsx
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	x := NewWithArgs([]string{"app", "--port", "80a", "--format", "xml", "--name", "x", "--unknown"})
+	x.Register(
+		Flag{Name: "port", Type: "int"},
+		Flag{Name: "format", Choices: []string{"json", "yaml"}},
+		Flag{Name: "region", Required: true},
+		Flag{Name: "name", Validate: func(v Value) error {
+			if len(v.MustString()) < 2 {
+				return errors.New("too short")
+			}
+			return nil
+		}},
+	)
+
+	require.Equal(t, "x", x.Fetch("name").MustString())
+	err := x.Parse()
+	require.EqualError(t, err, "invalid value `80a` for key: `--port`: not a valid int\n"+
+		"invalid value `xml` for key: `--format`: must be one of [json yaml]\n"+
+		"required key not specified: `--region`\n"+
+		"invalid value `x` for key: `--name`: too short")
+	require.Equal(t, err, x.Err())
+
+	x.SetArgs([]string{"app", "--port", "80", "--format", "json", "--region", "us", "--unknown"})
+	require.NoError(t, x.Parse())
+
+	x.SetStrict(true)
+	require.EqualError(t, x.Parse(), "unknown flag: `--unknown`")
+
+	x.Alias("region", "r")
+	x.SetArgs([]string{"app", "-r", "us"})
+	require.NoError(t, x.Parse())
+}
+
+func TestParseDurationType(t *testing.T) {
+	x := NewWithArgs([]string{"app", "--ttl", "7d", "--timeout", "P1DT12H", "--interval", "1.5h"})
+	x.Register(
+		Flag{Name: "ttl", Type: "duration"},
+		Flag{Name: "timeout", Type: "duration"},
+		Flag{Name: "interval", Type: "duration"},
+	)
+	require.NoError(t, x.Parse())
+	require.Equal(t, 168*time.Hour, x.Fetch("ttl").MustExtendedDuration())
+
+	x.SetArgs([]string{"app", "--ttl", "P1M"})
+	require.EqualError(t, x.Parse(), "invalid value `P1M` for key: `--ttl`: not a valid duration")
+}
+
+func TestParseBoolSwitch(t *testing.T) {
+	x := NewWithArgs([]string{"app", "--verbose", "input.txt", "-q=false", "--level", "3", "--enable-experimental", "out"})
+	x.Register(
+		Flag{Name: "verbose", Type: "bool"},
+		Flag{Name: "q", Type: "bool"},
+	)
+	require.NoError(t, x.Parse())
+	require.True(t, x.Fetch("verbose").MustBool())
+	require.False(t, x.Fetch("q").MustBool(true))
+	require.Equal(t, 3, x.Fetch("level").MustInt())
+	require.Equal(t, []string{"verbose", "q", "level", "enable-experimental"}, x.Keys())
+}
@@ -0,0 +1,125 @@
+package argsx
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// completeCommand is the hidden argument triggers dynamic completion
+const completeCommand = "__complete"
+
+// Candidate is a completion suggestion with optional description
+type Candidate struct {
+	Value       string
+	Description string
+}
+
+// CompletionFunc returns candidates of a flag value at runtime,
+// args are the words before the one being completed and toComplete is the partial word
+type CompletionFunc func(args []string, toComplete string) []Candidate
+
+// HandleCompletion prints completion candidates to w when the first arg is `__complete`
+// and reports whether it's handled, the program should exit when it returns true.
+// Each candidate is printed in a line as value and description separated by tab
+//
+//	if argsx.HandleCompletion(os.Stdout) {
+//		os.Exit(0)
+//	}
+func (x *Argsx) HandleCompletion(w io.Writer) bool {
+	args := x.getArgs()
+	if len(args) < 2 || args[1] != completeCommand {
+		return false
+	}
+
+	for _, candidate := range x.complete(args[2:]) {
+		if len(candidate.Description) > 0 {
+			fmt.Fprintf(w, "%s\t%s\n", candidate.Value, candidate.Description)
+		} else {
+			fmt.Fprintln(w, candidate.Value)
+		}
+	}
+	return true
+}
+
+// HandleCompletion prints completion candidates of the default instance to w
+func HandleCompletion(w io.Writer) bool {
+	return dx.HandleCompletion(w)
+}
+
+// complete returns candidates for the last word of words
+func (x *Argsx) complete(words []string) []Candidate {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	args, toComplete := words[:len(words)-1], words[len(words)-1]
+	flags := listedFlags(x.Flags(), false)
+
+	if key, partial, ok := strings.Cut(toComplete, "="); ok && strings.HasPrefix(key, "-") {
+		flag, found := lookupFlag(flags, strings.TrimLeft(key, "-"))
+		if !found {
+			return nil
+		}
+		candidates := flag.candidates(args, partial)
+		for idx := range candidates {
+			candidates[idx].Value = key + "=" + candidates[idx].Value
+		}
+		return candidates
+	}
+
+	if len(args) > 0 && strings.HasPrefix(args[len(args)-1], "-") && !strings.Contains(args[len(args)-1], "=") {
+		flag, found := lookupFlag(flags, strings.TrimLeft(args[len(args)-1], "-"))
+		if found && !flag.isBool() {
+			return flag.candidates(args, toComplete)
+		}
+	}
+
+	if !strings.HasPrefix(toComplete, "-") {
+		return nil
+	}
+	var candidates []Candidate
+	for _, flag := range flags {
+		for _, key := range flag.dashes() {
+			if strings.HasPrefix(key, toComplete) {
+				candidates = append(candidates, Candidate{key, flag.Usage})
+			}
+		}
+	}
+	return candidates
+}
+
+// candidates returns the values of flag start with toComplete in a new slice,
+// the slice returned by Complete is not modified
+func (f Flag) candidates(args []string, toComplete string) []Candidate {
+	var candidates []Candidate
+	if f.Complete != nil {
+		candidates = f.Complete(args, toComplete)
+	} else {
+		for _, choice := range f.Choices {
+			candidates = append(candidates, Candidate{Value: choice})
+		}
+	}
+
+	var filtered []Candidate
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate.Value, toComplete) {
+			filtered = append(filtered, candidate)
+		}
+	}
+	return filtered
+}
+
+// lookupFlag returns the flag by name
+func lookupFlag(flags []Flag, name string) (Flag, bool) {
+	for _, flag := range flags {
+		if flag.Name == name {
+			return flag, true
+		}
+		for _, alias := range flag.Aliases {
+			if strings.TrimLeft(alias, "-") == name {
+				return flag, true
+			}
+		}
+	}
+	return Flag{}, false
+}
@@ -0,0 +1,175 @@
+package argsx
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// nonIdent matches characters can't be used in a shell function name
+var nonIdent = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// WriteCompletion writes the completion script of registered flags for shell to w,
+// supported shells are bash, zsh and fish
+//
+//	WriteCompletion(os.Stdout, "bash") // source <(app completion bash)
+func (x *Argsx) WriteCompletion(w io.Writer, shell string) error {
+	name := x.Name()
+	flags := listedFlags(x.Flags(), false)
+	switch shell {
+	case "bash":
+		return writeBashCompletion(w, name, flags)
+	case "zsh":
+		return writeZshCompletion(w, name, flags)
+	case "fish":
+		return writeFishCompletion(w, name, flags)
+	default:
+		return fmt.Errorf("unsupported shell: `%s`", shell)
+	}
+}
+
+// WriteCompletion writes the completion script of the default instance for shell to w
+func WriteCompletion(w io.Writer, shell string) error {
+	return dx.WriteCompletion(w, shell)
+}
+
+// writeBashCompletion writes bash completion script
+func writeBashCompletion(w io.Writer, name string, flags []Flag) error {
+	fn := "_" + nonIdent.ReplaceAllString(name, "_") + "_completion"
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# bash completion for %s\n", name)
+	fmt.Fprintf(&sb, "%s() {\n", fn)
+	sb.WriteString("    local cur prev\n")
+	sb.WriteString("    cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	sb.WriteString("    prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	sb.WriteString("    case \"$prev\" in\n")
+	for _, flag := range flags {
+		var reply string
+		switch {
+		case flag.Complete != nil:
+			reply = fmt.Sprintf("compgen -W \"$(\"${COMP_WORDS[0]}\" %s \"${COMP_WORDS[@]:1:COMP_CWORD}\" 2>/dev/null | cut -f1)\" -- \"$cur\"", completeCommand)
+		case len(flag.Choices) > 0:
+			reply = fmt.Sprintf("compgen -W %s -- \"$cur\"", shellQuote(strings.Join(flag.Choices, " ")))
+		case flag.Hint == HintFile:
+			reply = "compgen -f -- \"$cur\""
+		case flag.Hint == HintDir:
+			reply = "compgen -d -- \"$cur\""
+		default:
+			continue
+		}
+		fmt.Fprintf(&sb, "        %s)\n", strings.Join(flag.dashes(), "|"))
+		fmt.Fprintf(&sb, "            COMPREPLY=($(%s))\n", reply)
+		sb.WriteString("            return\n")
+		sb.WriteString("            ;;\n")
+	}
+	sb.WriteString("    esac\n")
+	names := make([]string, 0, len(flags))
+	for _, flag := range flags {
+		names = append(names, flag.dashes()...)
+	}
+	fmt.Fprintf(&sb, "    COMPREPLY=($(compgen -W %s -- \"$cur\"))\n", shellQuote(strings.Join(names, " ")))
+	sb.WriteString("}\n")
+	fmt.Fprintf(&sb, "complete -o default -F %s %s\n", fn, name)
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeZshCompletion writes zsh completion script
+func writeZshCompletion(w io.Writer, name string, flags []Flag) error {
+	fn := "_" + nonIdent.ReplaceAllString(name, "_")
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "#compdef %s\n\n", name)
+	fmt.Fprintf(&sb, "%s_dynamic() {\n", fn)
+	sb.WriteString("    local -a candidates\n")
+	fmt.Fprintf(&sb, "    candidates=(\"${(@f)$(${words[1]} %s \"${(@)words[2,CURRENT]}\" 2>/dev/null)}\")\n", completeCommand)
+	sb.WriteString("    candidates=(\"${(@)candidates//:/\\:}\")\n")
+	sb.WriteString("    candidates=(\"${(@)candidates//$'\\t'/:}\")\n")
+	sb.WriteString("    _describe 'value' candidates\n")
+	sb.WriteString("}\n\n")
+	fmt.Fprintf(&sb, "%s() {\n", fn)
+	sb.WriteString("    _arguments -s")
+	for _, flag := range flags {
+		spec := "[" + zshEscape(flag.Usage) + "]"
+		if !flag.isBool() {
+			action := ""
+			switch {
+			case flag.Complete != nil:
+				action = fn + "_dynamic"
+			case len(flag.Choices) > 0:
+				choices := make([]string, 0, len(flag.Choices))
+				for _, choice := range flag.Choices {
+					choices = append(choices, strings.ReplaceAll(zshEscape(choice), " ", `\ `))
+				}
+				action = "(" + strings.Join(choices, " ") + ")"
+			case flag.Hint == HintFile:
+				action = "_files"
+			case flag.Hint == HintDir:
+				action = "_files -/"
+			}
+			spec += ":" + zshEscape(flag.Name) + ":" + action
+		}
+		// the aliases exclude each other
+		names := flag.dashes()
+		exclusion := ""
+		if len(names) > 1 {
+			exclusion = "(" + strings.Join(names, " ") + ")"
+		}
+		for _, name := range names {
+			sb.WriteString(" \\\n        " + shellQuote(exclusion+name+spec))
+		}
+	}
+	sb.WriteString("\n}\n\n")
+	fmt.Fprintf(&sb, "if [ \"$funcstack[1]\" = \"%s\" ]; then\n", fn)
+	fmt.Fprintf(&sb, "    %s \"$@\"\n", fn)
+	sb.WriteString("else\n")
+	fmt.Fprintf(&sb, "    compdef %s %s\n", fn, name)
+	sb.WriteString("fi\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeFishCompletion writes fish completion script
+func writeFishCompletion(w io.Writer, name string, flags []Flag) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# fish completion for %s\n", name)
+	for _, flag := range flags {
+		fmt.Fprintf(&sb, "complete -c %s", name)
+		for _, key := range flag.dashes() {
+			if strings.HasPrefix(key, "--") {
+				fmt.Fprintf(&sb, " -l %s", key[2:])
+			} else {
+				fmt.Fprintf(&sb, " -s %s", key[1:])
+			}
+		}
+		if len(flag.Usage) > 0 {
+			fmt.Fprintf(&sb, " -d %s", shellQuote(flag.Usage))
+		}
+		switch {
+		case flag.isBool():
+		case flag.Complete != nil:
+			fmt.Fprintf(&sb, " -x -a %s", shellQuote(fmt.Sprintf("(%s %s (commandline -opc)[2..-1] (commandline -ct))", name, completeCommand)))
+		case len(flag.Choices) > 0:
+			fmt.Fprintf(&sb, " -x -a %s", shellQuote(strings.Join(flag.Choices, " ")))
+		case flag.Hint == HintFile:
+			sb.WriteString(" -r -F")
+		case flag.Hint == HintDir:
+			sb.WriteString(" -x -a '(__fish_complete_directories)'")
+		default:
+			sb.WriteString(" -x")
+		}
+		sb.WriteString("\n")
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// shellQuote quotes s with single quotes for POSIX shells and fish
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// zshEscape escapes the characters have special meaning in _arguments specs
+func zshEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`, ":", `\:`).Replace(s)
+}
@@ -0,0 +1,35 @@
+package argsx
+
+// migrateDeprecated warns the deprecated flags specified in args and copies
+// their values to the replacement keys not specified
+func (s *Snapshot) migrateDeprecated() {
+	for _, flag := range s.flags {
+		if len(flag.Deprecated) == 0 {
+			continue
+		}
+		value, ok := s.values[s.resolver.canonical(flag.Name)]
+		if !ok {
+			continue
+		}
+
+		s.warnf("flag `%s` is deprecated: %s", value.fullkey, flag.Deprecated)
+		if len(flag.ReplacedBy) == 0 {
+			continue
+		}
+		replacement := s.resolver.canonical(flag.ReplacedBy)
+		if _, ok = s.values[replacement]; !ok {
+			s.values[replacement] = value
+		}
+	}
+}
+
+// deprecation returns the annotation of deprecated flag for help and docs
+func (f Flag) deprecation() string {
+	if len(f.Deprecated) == 0 {
+		return ""
+	}
+	if len(f.ReplacedBy) > 0 {
+		return "deprecated, use " + dash(f.ReplacedBy) + ": " + f.Deprecated
+	}
+	return "deprecated: " + f.Deprecated
+}
@@ -0,0 +1,48 @@
+package argsx
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprecated(t *testing.T) {
+	warnings := new(bytes.Buffer)
+	x := NewWithArgs([]string{"app", "--listen", "8080", "--old-mode"})
+	x.SetWarnWriter(warnings)
+	x.Register(
+		Flag{Name: "port", Type: "int", Usage: "listen port"},
+		Flag{Name: "listen", Type: "int", Usage: "listen port", Deprecated: "renamed in v2", ReplacedBy: "port"},
+		Flag{Name: "old-mode", Type: "bool", Deprecated: "no longer has effect"},
+	)
+
+	require.NoError(t, x.Parse())
+	require.Equal(t, 8080, x.Fetch("port").MustInt())
+	require.Equal(t, 8080, x.Fetch("listen").MustInt())
+	require.Equal(t, "warning: flag `--listen` is deprecated: renamed in v2\n"+
+		"warning: flag `--old-mode` is deprecated: no longer has effect\n", warnings.String())
+
+	// warnings are written once until args changed
+	warnings.Reset()
+	x.SetStrict(false)
+	x.Fetch("port")
+	require.NoError(t, x.Reload())
+	require.NoError(t, x.Parse())
+	require.Empty(t, warnings.String())
+	x.SetArgs([]string{"app", "--old-mode"})
+	x.Fetch("port")
+	require.Equal(t, "warning: flag `--old-mode` is deprecated: no longer has effect\n", warnings.String())
+
+	x.SetArgs([]string{"app", "--listen", "8080", "--port", "9090"})
+	require.Equal(t, 9090, x.Fetch("port").MustInt())
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, x.WriteHelp(buf))
+	require.Contains(t, buf.String(), "  --listen int   listen port (deprecated, use --port: renamed in v2)\n")
+
+	buf.Reset()
+	x.SetArgs([]string{"app", "__complete", "--"})
+	require.True(t, x.HandleCompletion(buf))
+	require.Equal(t, "--port\tlisten port\n", buf.String())
+}
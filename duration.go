@@ -0,0 +1,194 @@
+package argsx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	day  = 24 * time.Hour
+	week = 7 * day
+)
+
+var (
+	// durationSegment matches a number with unit like 1.5h or 7d
+	durationSegment = regexp.MustCompile(`^(\d+(?:\.\d*)?|\.\d+)(ns|us|µs|μs|ms|s|m|h|d|w)`)
+	// isoDuration matches ISO 8601 durations like P1W, P1DT12H or PT0.5S
+	isoDuration = regexp.MustCompile(`^P(?:(\d+(?:\.\d+)?)W)?(?:(\d+(?:\.\d+)?)D)?(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+)
+
+// ParseDuration parses a duration string like time.ParseDuration with two extensions:
+// the units "d" (24h) and "w" (7d), and ISO 8601 durations with weeks, days, hours, minutes and seconds.
+// Years and months of ISO 8601 are rejected because the length is ambiguous
+//
+//	ParseDuration("7d") // 168h, nil
+//	ParseDuration("2w3d12h") // 420h, nil
+//	ParseDuration("P1DT12H") // 36h, nil
+//	ParseDuration("-PT1.5S") // -1.5s, nil
+//	ParseDuration("P1M") // 0, error
+func ParseDuration(s string) (time.Duration, error) {
+	orig := s
+	neg := false
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	var (
+		d   time.Duration
+		err error
+	)
+	if strings.HasPrefix(s, "P") {
+		d, err = parseISODuration(s)
+	} else {
+		d, err = parseUnitDuration(s)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration `%s`: %w", orig, err)
+	}
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// parseUnitDuration parses unsigned Go style duration supports d and w units
+func parseUnitDuration(s string) (time.Duration, error) {
+	if s == "0" {
+		return 0, nil
+	}
+	if len(s) == 0 {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	var (
+		d   time.Duration
+		err error
+	)
+	for len(s) > 0 {
+		match := durationSegment.FindStringSubmatch(s)
+		if match == nil {
+			return 0, fmt.Errorf("unexpected `%s`", s)
+		}
+		s = s[len(match[0]):]
+
+		var segment time.Duration
+		switch match[2] {
+		case "d", "w":
+			unit := day
+			if match[2] == "w" {
+				unit = week
+			}
+			var n float64
+			if n, err = strconv.ParseFloat(match[1], 64); err != nil {
+				return 0, err
+			}
+			if segment, err = scaleDuration(n, unit); err != nil {
+				return 0, err
+			}
+		default:
+			if segment, err = time.ParseDuration(match[0]); err != nil {
+				return 0, err
+			}
+		}
+		if d, err = addDuration(d, segment); err != nil {
+			return 0, err
+		}
+	}
+	return d, nil
+}
+
+// parseISODuration parses unsigned ISO 8601 duration
+func parseISODuration(s string) (time.Duration, error) {
+	match := isoDuration.FindStringSubmatch(s)
+	if match == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return 0, fmt.Errorf("unsupported ISO 8601 duration")
+	}
+
+	var d time.Duration
+	for i, unit := range []time.Duration{week, day, time.Hour, time.Minute, time.Second} {
+		if len(match[i+1]) == 0 {
+			continue
+		}
+		n, err := strconv.ParseFloat(match[i+1], 64)
+		if err != nil {
+			return 0, err
+		}
+		segment, err := scaleDuration(n, unit)
+		if err != nil {
+			return 0, err
+		}
+		if d, err = addDuration(d, segment); err != nil {
+			return 0, err
+		}
+	}
+	return d, nil
+}
+
+// errDurationOverflow is returned when the duration exceeds the range of time.Duration
+var errDurationOverflow = errors.New("duration out of range")
+
+// scaleDuration returns n units, the result must not overflow time.Duration
+func scaleDuration(n float64, unit time.Duration) (time.Duration, error) {
+	f := n * float64(unit)
+	if f >= math.MaxInt64 {
+		return 0, errDurationOverflow
+	}
+	return time.Duration(f), nil
+}
+
+// addDuration returns the sum of two non-negative durations, the result must not overflow time.Duration
+func addDuration(d, segment time.Duration) (time.Duration, error) {
+	if d > math.MaxInt64-segment {
+		return 0, errDurationOverflow
+	}
+	return d + segment, nil
+}
+
+// FormatDuration formats d using the units accepted by ParseDuration,
+// whole weeks and days are printed with w and d and zero units are omitted
+//
+//	FormatDuration(168 * time.Hour) // "1w"
+//	FormatDuration(36 * time.Hour) // "1d12h"
+//	FormatDuration(90 * time.Second) // "1m30s"
+//	FormatDuration(0) // "0s"
+func FormatDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+
+	// the magnitude is unsigned to format math.MinInt64 which can not be negated
+	var sb strings.Builder
+	u := uint64(d)
+	if d < 0 {
+		sb.WriteByte('-')
+		u = -u
+	}
+	for _, unit := range []struct {
+		size uint64
+		name string
+	}{{uint64(week), "w"}, {uint64(day), "d"}, {uint64(time.Hour), "h"}, {uint64(time.Minute), "m"}} {
+		if n := u / unit.size; n > 0 {
+			sb.WriteString(strconv.FormatUint(n, 10) + unit.name)
+			u -= n * unit.size
+		}
+	}
+	if u > 0 {
+		sb.WriteString(time.Duration(u).String())
+	}
+	return sb.String()
+}
+
+// WithExtendedDuration parses elements by ParseDuration instead of time.ParseDuration
+//
+//	NewValue("1d,2w").DurationSlice(WithExtendedDuration()) // []time.Duration{24h, 336h}, nil
+func WithExtendedDuration() Option[time.Duration] {
+	return func(opts *options[time.Duration]) {
+		opts.parse = ParseDuration
+	}
+}
@@ -0,0 +1,135 @@
+package argsx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// redacted replaces the payload of secret flags in exports
+const redacted = "[REDACTED]"
+
+var (
+	// plainYAMLKey matches keys can be written in YAML without quotes
+	plainYAMLKey = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+	// plainEnvValue matches values can be written in env lines without quotes
+	plainEnvValue = regexp.MustCompile(`^[A-Za-z0-9_.,:/@%+=-]*$`)
+)
+
+// entry is an exported key and payload pair
+type entry struct {
+	key     string
+	env     string
+	payload string
+}
+
+// entries returns effective values sorted by key, the payloads of secret flags are redacted
+func (s *Snapshot) entries() []entry {
+	flags := make(map[string]Flag, len(s.flags))
+	for _, flag := range s.flags {
+		flags[s.resolver.canonical(flag.Name)] = flag
+	}
+
+	entries := make([]entry, 0, len(s.values))
+	for key, value := range s.values {
+		e := entry{key: key, payload: value.payload}
+		if flag, ok := flags[key]; ok {
+			e.env = flag.Env
+		}
+		if value.secret {
+			e.payload = redacted
+		}
+		if len(e.env) == 0 {
+			e.env = strings.ToUpper(nonIdent.ReplaceAllString(key, "_"))
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+	return entries
+}
+
+// Export writes all effective values from args, env and defaults to w in format,
+// the payloads of secret flags are redacted. Supported formats are:
+//
+//	json  {"key": "value"}
+//	yaml  key: "value"
+//	env   KEY=value, the name is Flag.Env or upper case key
+//	args  --key=value in a line quoted for shell
+func (x *Argsx) Export(w io.Writer, format string) error {
+	entries := x.Snapshot().entries()
+	var sb strings.Builder
+	switch format {
+	case "json":
+		values := make(map[string]string, len(entries))
+		for _, e := range entries {
+			values[e.key] = e.payload
+		}
+		bs, err := json.MarshalIndent(values, "", "  ")
+		if err != nil {
+			return err
+		}
+		sb.Write(bs)
+		sb.WriteByte('\n')
+	case "yaml":
+		for _, e := range entries {
+			key := e.key
+			if !plainYAMLKey.MatchString(key) {
+				key = strconv.Quote(key)
+			}
+			fmt.Fprintf(&sb, "%s: %s\n", key, strconv.Quote(e.payload))
+		}
+	case "env":
+		for _, e := range entries {
+			payload := e.payload
+			if !plainEnvValue.MatchString(payload) {
+				payload = shellQuote(payload)
+			}
+			fmt.Fprintf(&sb, "%s=%s\n", e.env, payload)
+		}
+	case "args":
+		args := x.ExportArgs()
+		for idx, arg := range args {
+			if !plainEnvValue.MatchString(arg) {
+				args[idx] = shellQuote(arg)
+			}
+		}
+		sb.WriteString(strings.Join(args, " ") + "\n")
+	default:
+		return fmt.Errorf("unsupported export format: `%s`", format)
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// ExportArgs returns all effective values as args can be parsed again,
+// the payloads of secret flags are redacted
+//
+//	ExportArgs() // []string{"--debug", "--port=8080"}
+func (x *Argsx) ExportArgs() []string {
+	entries := x.Snapshot().entries()
+	args := make([]string, 0, len(entries))
+	for _, e := range entries {
+		arg := dash(e.key)
+		if len(e.payload) > 0 {
+			arg += "=" + e.payload
+		}
+		args = append(args, arg)
+	}
+	return args
+}
+
+// Export writes all effective values of the default instance to w in format
+func Export(w io.Writer, format string) error {
+	return dx.Export(w, format)
+}
+
+// ExportArgs returns all effective values of the default instance as args
+func ExportArgs() []string {
+	return dx.ExportArgs()
+}
@@ -0,0 +1,51 @@
+package argsx
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExport(t *testing.T) {
+	x := NewWithArgs([]string{"app", "--port", "8080", "--db.password", "p@ss", "--name", "John Smith", "--debug"})
+	x.SetWarnWriter(nil)
+	x.Register(
+		Flag{Name: "db.password", Secret: true, Env: "DB_PASS"},
+		Flag{Name: "region", Default: "us-east"},
+	)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, x.Export(buf, "json"))
+	require.JSONEq(t, `{"port": "8080", "db.password": "[REDACTED]", "name": "John Smith", "debug": "", "region": "us-east"}`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, x.Export(buf, "yaml"))
+	require.Equal(t, `db.password: "[REDACTED]"
+debug: ""
+name: "John Smith"
+port: "8080"
+region: "us-east"
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, x.Export(buf, "env"))
+	require.Equal(t, `DB_PASS='[REDACTED]'
+DEBUG=
+NAME='John Smith'
+PORT=8080
+REGION=us-east
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, x.Export(buf, "args"))
+	require.Equal(t, "'--db.password=[REDACTED]' --debug '--name=John Smith' --port=8080 --region=us-east\n", buf.String())
+
+	args := x.ExportArgs()
+	require.Equal(t, []string{"--db.password=[REDACTED]", "--debug", "--name=John Smith", "--port=8080", "--region=us-east"}, args)
+	reparsed := NewWithArgs(append([]string{"app"}, args...))
+	require.Equal(t, "John Smith", reparsed.Fetch("name").MustString())
+	require.True(t, reparsed.Fetch("debug").MustBool())
+
+	require.Error(t, x.Export(buf, "toml"))
+}
@@ -0,0 +1,219 @@
+package argsx
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+)
+
+// Hint tells shell completion what kind of value a flag accepts
+type Hint int
+
+const (
+	// HintNone completes nothing special for the value
+	HintNone Hint = iota
+	// HintFile completes file paths for the value
+	HintFile
+	// HintDir completes directory paths for the value
+	HintDir
+)
+
+// Flag describes a key accepted by Argsx, it's used for help and shell completion
+type Flag struct {
+	// Name is the key without leading '-', a single letter name is written as -n otherwise --name
+	Name string
+	// Aliases are the other names of the flag, like a single letter short name
+	Aliases []string
+	// Usage is the short description of the flag
+	Usage string
+	// Type is the name of value type like string, int or duration, Parse checks the
+	// payload of bool, int, int8-int64, uint, uint64, float32, float64 and duration.
+	// The duration accepts the syntax of ParseDuration like 7d or P1D, read it by ExtendedDuration.
+	// The bool type flag is a switch and takes no value, default is string
+	Type string
+	// Default is the payload used when the key is not specified in args
+	Default string
+	// Env is the environment variable used when the key is not specified in args,
+	// it takes precedence over Default
+	Env string
+	// Choices are the allowed values of the flag
+	Choices []string
+	// Hint is the kind of value for shell completion
+	Hint Hint
+	// Required reports an error by Parse when the key is not specified by args, env or Default
+	Required bool
+	// Validate checks the value when parsing, the error is reported by Parse
+	Validate func(v Value) error
+	// Visibility controls where the flag is listed and whether it's accepted, default is Visible
+	Visibility Visibility
+	// Secret marks the value is sensitive: it's redacted in the string getters like String, StringSlice
+	// and Enum, fmt, exports and Parse errors, read it by Reveal. The payload is read from file by
+	// --name-file path or from stdin when it's `-`, and a warning is written when it's specified in argv
+	// directly. The payload is kept as a string in memory, use RevealBytes and WipeSecrets to limit copies
+	Secret bool
+	// Deprecated is the deprecation message of the flag, empty means not deprecated.
+	// A warning is written when the deprecated flag is specified in args
+	Deprecated string
+	// ReplacedBy is the key of the deprecated flag renamed to, the value is copied
+	// to it when the deprecated flag is specified and the key is not
+	ReplacedBy string
+	// Complete returns candidates of the value at runtime, generated
+	// completion scripts ask the program by the hidden `__complete` argument
+	Complete CompletionFunc
+}
+
+// dash returns the flag name with leading '-' or '--'
+func (f Flag) dash() string {
+	return dash(f.Name)
+}
+
+// dashes returns the aliases and the name of flag with leading '-' or '--', like [-c --config]
+func (f Flag) dashes() []string {
+	names := make([]string, 0, len(f.Aliases)+1)
+	for _, alias := range f.Aliases {
+		names = append(names, dash(strings.TrimLeft(alias, "-")))
+	}
+	return append(names, f.dash())
+}
+
+// isBool reports whether the flag is a switch takes no value
+func (f Flag) isBool() bool {
+	return f.Type == "bool"
+}
+
+// typeName returns the type of flag value, default is string
+func (f Flag) typeName() string {
+	if len(f.Type) == 0 {
+		return "string"
+	}
+	return f.Type
+}
+
+// defaultText returns the Default for help and docs, the default of duration
+// type is formatted by FormatDuration like 1w instead of 168h0m0s
+func (f Flag) defaultText() string {
+	if f.Type == "duration" {
+		if d, err := ParseDuration(f.Default); err == nil {
+			return FormatDuration(d)
+		}
+	}
+	return f.Default
+}
+
+// dash returns the key with leading '-' if it's a single letter otherwise '--'
+func dash(key string) string {
+	if len(key) == 1 {
+		return "-" + key
+	}
+	return "--" + key
+}
+
+// Register describes flags for help and completion, registered flags with
+// Env or Default are filled when the key is not specified in args.
+// Registering a flag again replaces it and its aliases
+//
+//	Register(Flag{Name: "format", Usage: "output format", Default: "json", Choices: []string{"json", "yaml"}})
+func (x *Argsx) Register(flags ...Flag) {
+	x.mux.Lock()
+	defer x.mux.Unlock()
+
+	for _, flag := range flags {
+		flag.Name = strings.TrimLeft(flag.Name, "-")
+		idx := x.flagIndex(flag.Name)
+		if idx >= 0 {
+			x.resolver.unalias(flag.Name, x.flags[idx].Aliases...)
+		}
+		x.resolver.alias(flag.Name, flag.Aliases...)
+		if idx >= 0 {
+			x.flags[idx] = flag
+			continue
+		}
+		x.flags = append(x.flags, flag)
+	}
+	x.reset()
+}
+
+// Flags returns registered flags in registration order
+func (x *Argsx) Flags() []Flag {
+	x.mux.Lock()
+	defer x.mux.Unlock()
+	return append([]Flag(nil), x.flags...)
+}
+
+// flagIndex returns the index of registered flag by name or -1
+func (x *Argsx) flagIndex(name string) int {
+	for idx, flag := range x.flags {
+		if flag.Name == name {
+			return idx
+		}
+	}
+	return -1
+}
+
+// Name returns the program name from the first arg
+func (x *Argsx) Name() string {
+	args := x.getArgs()
+	if len(args) == 0 {
+		return ""
+	}
+	return filepath.Base(args[0])
+}
+
+// WriteHelp writes usage of registered flags to w
+//
+//	Usage: app [flags]
+//
+//	Flags:
+//	  --format string    output format (choices: json, yaml) (default "json")
+func (x *Argsx) WriteHelp(w io.Writer) error {
+	flags := listedFlags(x.Flags(), true)
+	if _, err := fmt.Fprintf(w, "Usage: %s [flags]\n", x.Name()); err != nil {
+		return err
+	}
+	if len(flags) == 0 {
+		return nil
+	}
+
+	if _, err := fmt.Fprint(w, "\nFlags:\n"); err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 3, ' ', 0)
+	for _, flag := range flags {
+		name := strings.Join(flag.dashes(), ", ")
+		if !flag.isBool() {
+			name += " " + flag.typeName()
+		}
+		usage := flag.Usage
+		if len(flag.Choices) > 0 {
+			usage += fmt.Sprintf(" (choices: %s)", strings.Join(flag.Choices, ", "))
+		}
+		if len(flag.Default) > 0 {
+			usage += fmt.Sprintf(" (default %q)", flag.defaultText())
+		}
+		if len(flag.Env) > 0 {
+			usage += fmt.Sprintf(" (env $%s)", flag.Env)
+		}
+		if len(flag.Deprecated) > 0 {
+			usage += fmt.Sprintf(" (%s)", flag.deprecation())
+		}
+		if flag.Visibility == Experimental {
+			usage += " (experimental)"
+		}
+		if _, err := fmt.Fprintf(tw, "  %s\t%s\n", name, strings.TrimSpace(usage)); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+// Register describes flags for help and completion on the default instance
+func Register(flags ...Flag) {
+	dx.Register(flags...)
+}
+
+// WriteHelp writes usage of registered flags of the default instance to w
+func WriteHelp(w io.Writer) error {
+	return dx.WriteHelp(w)
+}
@@ -0,0 +1,164 @@
+package argsx
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newFlagArgsx() *Argsx {
+	x := NewWithArgs([]string{"/usr/bin/app", "--verbose"})
+	x.Register(
+		Flag{Name: "format", Usage: "output format", Default: "json", Choices: []string{"json", "yaml", "table"}},
+		Flag{Name: "config", Usage: "config file", Hint: HintFile},
+		Flag{Name: "workdir", Usage: "working directory", Hint: HintDir},
+		Flag{Name: "port", Usage: "listen port", Type: "int"},
+		Flag{Name: "verbose", Usage: "verbose output", Type: "bool"},
+		Flag{Name: "v", Usage: "print version", Type: "bool"},
+	)
+	return x
+}
+
+func TestRegister(t *testing.T) {
+	x := newFlagArgsx()
+	require.Equal(t, "app", x.Name())
+	require.Len(t, x.Flags(), 6)
+
+	value, err := x.Fetch("format").String()
+	require.NoError(t, err)
+	require.Equal(t, "json", value)
+	require.True(t, x.Fetch("verbose").MustBool())
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, x.WriteHelp(buf))
+	require.Equal(t, `Usage: app [flags]
+
+Flags:
+  --format string    output format (choices: json, yaml, table) (default "json")
+  --config string    config file
+  --workdir string   working directory
+  --port int         listen port
+  --verbose          verbose output
+  -v                 print version
+`, buf.String())
+}
+
+func TestWriteCompletion(t *testing.T) {
+	x := newFlagArgsx()
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, x.WriteCompletion(buf, "bash"))
+	require.Contains(t, buf.String(), "complete -o default -F _app_completion app\n")
+	require.Contains(t, buf.String(), "COMPREPLY=($(compgen -W 'json yaml table' -- \"$cur\"))")
+	require.Contains(t, buf.String(), "--config)\n            COMPREPLY=($(compgen -f -- \"$cur\"))")
+
+	buf.Reset()
+	require.NoError(t, x.WriteCompletion(buf, "zsh"))
+	require.Contains(t, buf.String(), "#compdef app\n")
+	require.Contains(t, buf.String(), `'--format[output format]:format:(json yaml table)'`)
+	require.Contains(t, buf.String(), `'--workdir[working directory]:workdir:_files -/'`)
+	require.Contains(t, buf.String(), `'--verbose[verbose output]'`)
+
+	buf.Reset()
+	require.NoError(t, x.WriteCompletion(buf, "fish"))
+	require.Contains(t, buf.String(), "complete -c app -l format -d 'output format' -x -a 'json yaml table'\n")
+	require.Contains(t, buf.String(), "complete -c app -s v -d 'print version'\n")
+
+	require.Error(t, x.WriteCompletion(buf, "powershell"))
+}
+
+func TestHandleCompletion(t *testing.T) {
+	x := newFlagArgsx()
+	envs := []Candidate{{"dev", "development"}, {"staging", ""}, {"prod", "production"}}
+	x.Register(Flag{Name: "env", Usage: "target environment", Complete: func(args []string, toComplete string) []Candidate {
+		return envs
+	}})
+
+	buf := new(bytes.Buffer)
+	require.False(t, x.HandleCompletion(buf))
+
+	x.SetArgs([]string{"app", "__complete", "--env", "d"})
+	require.True(t, x.HandleCompletion(buf))
+	require.Equal(t, "dev\tdevelopment\n", buf.String())
+
+	buf.Reset()
+	x.SetArgs([]string{"app", "__complete", "--verbose", "--env="})
+	require.True(t, x.HandleCompletion(buf))
+	require.Equal(t, "--env=dev\tdevelopment\n--env=staging\n--env=prod\tproduction\n", buf.String())
+	require.Equal(t, []Candidate{{"dev", "development"}, {"staging", ""}, {"prod", "production"}}, envs)
+
+	buf.Reset()
+	x.SetArgs([]string{"app", "__complete", "--env", "p"})
+	require.True(t, x.HandleCompletion(buf))
+	require.Equal(t, "prod\tproduction\n", buf.String())
+	require.Equal(t, []Candidate{{"dev", "development"}, {"staging", ""}, {"prod", "production"}}, envs)
+
+	buf.Reset()
+	x.SetArgs([]string{"app", "__complete", "--format", "t"})
+	require.True(t, x.HandleCompletion(buf))
+	require.Equal(t, "table\n", buf.String())
+
+	buf.Reset()
+	x.SetArgs([]string{"app", "__complete", "--verbose", "--w"})
+	require.True(t, x.HandleCompletion(buf))
+	require.Equal(t, "--workdir\tworking directory\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, x.WriteCompletion(buf, "bash"))
+	require.Contains(t, buf.String(), `"$("${COMP_WORDS[0]}" __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null | cut -f1)"`)
+}
+
+func TestWriteMan(t *testing.T) {
+	x := newFlagArgsx()
+	x.Register(Flag{Name: "token", Usage: "API token", Env: "APP_TOKEN"})
+	t.Setenv("APP_TOKEN", "secret")
+	require.Equal(t, "secret", x.Fetch("token").MustString())
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, x.WriteMan(buf, ManHeader{Short: "demo app", Source: "app 1.0"}))
+	require.Contains(t, buf.String(), `.TH "APP" "1" "" "app 1.0" ""`+"\n.SH NAME\napp \\- demo app\n")
+	require.Contains(t, buf.String(), ".TP\n\\fB\\-\\-format\\fR \\fIstring\\fR\noutput format\n.br\nChoices: json, yaml, table\n.br\nDefault: json\n")
+	require.Contains(t, buf.String(), ".SH ENVIRONMENT\n.TP\n\\fBAPP_TOKEN\\fR\n")
+
+	filename, err := x.GenMan(t.TempDir(), ManHeader{Section: "8"})
+	require.NoError(t, err)
+	require.Equal(t, "app.8", filepath.Base(filename))
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	x := newFlagArgsx()
+	x.Register(
+		Flag{Name: "token", Usage: "API token", Env: "APP_TOKEN"},
+		Flag{Name: "output", Usage: "output format", Deprecated: "use --format instead."},
+	)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, x.WriteMarkdown(buf, MarkdownHeader{Short: "demo app"}))
+	require.Contains(t, buf.String(), "# app\n\ndemo app\n\n## Usage\n\n```\napp [flags]\n```\n")
+	require.Contains(t, buf.String(), "| `--format` | string | `json` |  | output format (choices: `json`, `yaml`, `table`) |\n")
+	require.Contains(t, buf.String(), "| `--token` | string |  | `APP_TOKEN` | API token |\n")
+	require.Contains(t, buf.String(), "| `--output` | string |  |  | **Deprecated:** use --format instead. output format |\n")
+
+	filename, err := x.GenMarkdown(t.TempDir(), MarkdownHeader{})
+	require.NoError(t, err)
+	require.Equal(t, "app.md", filepath.Base(filename))
+}
+
+func TestDurationDefault(t *testing.T) {
+	x := NewWithArgs([]string{"app"})
+	x.Register(Flag{Name: "ttl", Usage: "cache ttl", Type: "duration", Default: "168h0m0s"})
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, x.WriteHelp(buf))
+	require.Contains(t, buf.String(), `--ttl duration   cache ttl (default "1w")`)
+
+	buf.Reset()
+	require.NoError(t, x.WriteMan(buf, ManHeader{}))
+	require.Contains(t, buf.String(), "Default: 1w\n")
+
+	buf.Reset()
+	require.NoError(t, x.WriteMarkdown(buf, MarkdownHeader{}))
+	require.Contains(t, buf.String(), "| `--ttl` | duration | `1w` |")
+}
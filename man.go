@@ -0,0 +1,142 @@
+package argsx
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ManHeader describes the header and the descriptions of generated man pages
+type ManHeader struct {
+	// Title is the page title, default is upper case program name
+	Title string
+	// Section is the manual section, default is 1
+	Section string
+	// Date is the date of last change printed in the footer
+	Date string
+	// Source is the source of the program like the name and version of package
+	Source string
+	// Manual is the title of the manual
+	Manual string
+	// Short is the one line description in NAME section
+	Short string
+	// Long is the DESCRIPTION section
+	Long string
+}
+
+// WriteMan writes the man page in roff format to w, registered flags are
+// listed in OPTIONS section and the flags bound to env are listed in ENVIRONMENT section
+//
+//	WriteMan(os.Stdout, ManHeader{Short: "print greetings"}) // app.1
+func (x *Argsx) WriteMan(w io.Writer, header ManHeader) error {
+	name := x.Name()
+	flags := listedFlags(x.Flags(), true)
+	if len(header.Title) == 0 {
+		header.Title = strings.ToUpper(name)
+	}
+	if len(header.Section) == 0 {
+		header.Section = "1"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(".TH")
+	for _, field := range []string{header.Title, header.Section, header.Date, header.Source, header.Manual} {
+		sb.WriteString(` "` + strings.ReplaceAll(roffEscape(field), `"`, `""`) + `"`)
+	}
+	sb.WriteString("\n")
+	sb.WriteString(".SH NAME\n")
+	sb.WriteString(roffEscape(name))
+	if len(header.Short) > 0 {
+		sb.WriteString(` \- ` + roffEscape(header.Short))
+	}
+	sb.WriteString("\n.SH SYNOPSIS\n")
+	fmt.Fprintf(&sb, ".B %s\n[\\fIOPTIONS\\fR]\n", roffEscape(name))
+	if len(header.Long) > 0 {
+		sb.WriteString(".SH DESCRIPTION\n")
+		sb.WriteString(roffText(header.Long) + "\n")
+	}
+
+	if len(flags) > 0 {
+		sb.WriteString(".SH OPTIONS\n")
+		for _, flag := range flags {
+			sb.WriteString(".TP\n")
+			sb.WriteString(`\fB` + roffEscape(strings.Join(flag.dashes(), ", ")) + `\fR`)
+			if !flag.isBool() {
+				sb.WriteString(` \fI` + roffEscape(flag.typeName()) + `\fR`)
+			}
+			sb.WriteString("\n")
+			sb.WriteString(roffText(flag.Usage))
+			if len(flag.Choices) > 0 {
+				sb.WriteString("\n.br\nChoices: " + roffEscape(strings.Join(flag.Choices, ", ")))
+			}
+			if len(flag.Default) > 0 {
+				sb.WriteString("\n.br\nDefault: " + roffEscape(flag.defaultText()))
+			}
+			if len(flag.Deprecated) > 0 {
+				sb.WriteString("\n.br\n" + roffEscape(flag.deprecation()))
+			}
+			if flag.Visibility == Experimental {
+				sb.WriteString("\n.br\nExperimental, requires " + roffEscape("--"+enableExperimental))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	var envs []Flag
+	for _, flag := range flags {
+		if len(flag.Env) > 0 {
+			envs = append(envs, flag)
+		}
+	}
+	if len(envs) > 0 {
+		sb.WriteString(".SH ENVIRONMENT\n")
+		for _, flag := range envs {
+			sb.WriteString(".TP\n")
+			sb.WriteString(`\fB` + roffEscape(flag.Env) + `\fR` + "\n")
+			fmt.Fprintf(&sb, "Used as \\fB%s\\fR when it is not specified in arguments.\n", roffEscape(flag.dash()))
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// GenMan writes the man page named like app.1 to dir and returns the file path,
+// dir is created if not exists
+func (x *Argsx) GenMan(dir string, header ManHeader) (string, error) {
+	if len(header.Section) == 0 {
+		header.Section = "1"
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	filename := filepath.Join(dir, x.Name()+"."+header.Section)
+	f, err := os.Create(filename)
+	if err != nil {
+		return "", err
+	}
+	if err = x.WriteMan(f, header); err != nil {
+		_ = f.Close()
+		return "", err
+	}
+	return filename, f.Close()
+}
+
+// roffEscape escapes backslashes and hyphens for roff
+func roffEscape(s string) string {
+	return strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(s)
+}
+
+// roffText escapes s and protects the lines start with control characters
+func roffText(s string) string {
+	lines := strings.Split(roffEscape(s), "\n")
+	for idx, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[idx] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,105 @@
+package argsx
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MarkdownHeader describes the title and descriptions of generated markdown reference
+type MarkdownHeader struct {
+	// Title is the top level heading, default is program name
+	Title string
+	// Short is the one line description under the title
+	Short string
+	// Long is the detail description follows Short
+	Long string
+}
+
+// WriteMarkdown writes the markdown reference of registered flags to w,
+// flags are rendered as a table with types, defaults, env names and deprecations
+//
+//	//go:generate go run ./cmd/docs
+//	WriteMarkdown(f, MarkdownHeader{Short: "print greetings"})
+func (x *Argsx) WriteMarkdown(w io.Writer, header MarkdownHeader) error {
+	name := x.Name()
+	flags := listedFlags(x.Flags(), true)
+	if len(header.Title) == 0 {
+		header.Title = name
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", header.Title)
+	for _, desc := range []string{header.Short, header.Long} {
+		if len(desc) > 0 {
+			sb.WriteString(desc + "\n\n")
+		}
+	}
+	fmt.Fprintf(&sb, "## Usage\n\n```\n%s [flags]\n```\n", name)
+
+	if len(flags) > 0 {
+		sb.WriteString("\n## Flags\n\n")
+		sb.WriteString("| Flag | Type | Default | Env | Description |\n")
+		sb.WriteString("|------|------|---------|-----|-------------|\n")
+		for _, flag := range flags {
+			var cells [5]string
+			cells[0] = "`" + strings.Join(flag.dashes(), "`, `") + "`"
+			cells[1] = flag.typeName()
+			if len(flag.Default) > 0 {
+				cells[2] = "`" + flag.defaultText() + "`"
+			}
+			if len(flag.Env) > 0 {
+				cells[3] = "`" + flag.Env + "`"
+			}
+
+			desc := flag.Usage
+			if len(flag.Choices) > 0 {
+				desc += " (choices: `" + strings.Join(flag.Choices, "`, `") + "`)"
+			}
+			if len(flag.Deprecated) > 0 {
+				desc = "**Deprecated:** " + flag.Deprecated + " " + desc
+				if len(flag.ReplacedBy) > 0 {
+					desc += " Use `" + dash(flag.ReplacedBy) + "` instead."
+				}
+			}
+			if flag.Visibility == Experimental {
+				desc = "**Experimental:** requires `--" + enableExperimental + "`. " + desc
+			}
+			cells[4] = strings.TrimSpace(desc)
+
+			for idx := range cells {
+				cells[idx] = markdownCell(cells[idx])
+			}
+			sb.WriteString("| " + strings.Join(cells[:], " | ") + " |\n")
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// GenMarkdown writes the markdown reference named like app.md to dir and returns the file path,
+// dir is created if not exists
+func (x *Argsx) GenMarkdown(dir string, header MarkdownHeader) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	filename := filepath.Join(dir, x.Name()+".md")
+	f, err := os.Create(filename)
+	if err != nil {
+		return "", err
+	}
+	if err = x.WriteMarkdown(f, header); err != nil {
+		_ = f.Close()
+		return "", err
+	}
+	return filename, f.Close()
+}
+
+// markdownCell escapes pipes and line breaks can't be used in table cell
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>").Replace(s)
+}
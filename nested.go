@@ -0,0 +1,209 @@
+package argsx
+
+import (
+	"strconv"
+	"time"
+)
+
+// getNestedDefault get the default value of nested slice, WithDefault specifies a single row
+func (opts options[T]) getNestedDefault() (t [][][]T) {
+	if opts.defaultV == nil {
+		return
+	}
+	return [][][]T{{opts.defaultV}}
+}
+
+// toSlices convert payload string to nested slice of T type, the payload is split into rows
+// by outer delimiter first and each row is converted by toSlice, empty rows are skipped
+func toSlices[T any](payload string, option *options[T], parse parser[T], dv ...T) ([][]T, error) {
+	rows, err := option.splitRows(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var slices [][]T
+	for _, row := range rows {
+		if len(row) == 0 {
+			continue
+		}
+		slice, err := toSlice(row, option, parse, dv...)
+		if err != nil {
+			return nil, err
+		}
+		slices = append(slices, slice)
+	}
+	return slices, nil
+}
+
+// WithOuterDelimiter specify the delimiter between rows of nested slice default is ";",
+// the delimiter of elements in a row is specified by WithDelimiter
+//
+//	WithOuterDelimiter[int]("|")
+func WithOuterDelimiter[T any](delimiter string) Option[T] {
+	return func(opts *options[T]) {
+		opts.outerDelimiter = delimiter
+	}
+}
+
+// StringSlices returns [][]string, empty elements are skipped and the elements of secret value are redacted
+//
+//	NewValue("a,b;c,d").StringSlices() // [][]string{{"a", "b"}, {"c", "d"}}, nil
+//	NewValue("").StringSlices() // nil, error
+//	NewValue("a-b|c").StringSlices(WithDelimiter[string]("-"), WithOuterDelimiter[string]("|")) // [][]string{{"a", "b"}, {"c"}}, nil
+func (v Value) StringSlices(opts ...Option[string]) ([][]string, error) {
+	option := getOpts(opts).secretOf(v)
+	return get(v, option.getNestedDefault(), func(payload string) ([][]string, error) {
+		slices, err := toSlices(payload, option, func(payload string) (string, error) {
+			return payload, nil
+		})
+		for _, slice := range slices {
+			v.redactSlice(slice)
+		}
+		return slices, err
+	})
+}
+
+// MustStringSlices returns [][]string if error not nil will be ignored
+//
+//	NewValue("a,b;c,d").MustStringSlices() // [][]string{{"a", "b"}, {"c", "d"}}
+//	NewValue("").MustStringSlices() // nil
+func (v Value) MustStringSlices(opts ...Option[string]) [][]string {
+	return must(v.StringSlices(opts...))
+}
+
+// BoolSlices returns [][]bool
+//
+//	NewValue("true,false;1,0").BoolSlices() // [][]bool{{true, false}, {true, false}}, nil
+//	NewValue("").BoolSlices() // nil, error
+//	NewValue("").BoolSlices(WithDefault[bool](true)) // [][]bool{{true}}, nil
+func (v Value) BoolSlices(opts ...Option[bool]) ([][]bool, error) {
+	option := getOpts(opts).secretOf(v)
+	return get(v, option.getNestedDefault(), func(payload string) ([][]bool, error) {
+		return toSlices(payload, option, strconv.ParseBool, true)
+	})
+}
+
+// MustBoolSlices returns [][]bool if error not nil will be ignored
+//
+//	NewValue("true,false;1,0").MustBoolSlices() // [][]bool{{true, false}, {true, false}}
+//	NewValue("").MustBoolSlices() // nil
+func (v Value) MustBoolSlices(opts ...Option[bool]) [][]bool {
+	return must(v.BoolSlices(opts...))
+}
+
+// DurationSlices returns [][]time.Duration
+//
+//	NewValue("1s,2s;1m").DurationSlices() // [][]time.Duration{{time.Second, time.Second*2}, {time.Minute}}, nil
+//	NewValue("").DurationSlices() // nil, error
+//	NewValue("1d;2w").DurationSlices(WithExtendedDuration()) // [][]time.Duration{{time.Hour*24}, {time.Hour*336}}, nil
+func (v Value) DurationSlices(opts ...Option[time.Duration]) ([][]time.Duration, error) {
+	option := getOpts(opts).secretOf(v)
+	return get(v, option.getNestedDefault(), func(payload string) ([][]time.Duration, error) {
+		return toSlices(payload, option, option.getParser(time.ParseDuration))
+	})
+}
+
+// MustDurationSlices returns [][]time.Duration if error not nil will be ignored
+//
+//	NewValue("1s,2s;1m").MustDurationSlices() // [][]time.Duration{{time.Second, time.Second*2}, {time.Minute}}
+//	NewValue("").MustDurationSlices() // nil
+func (v Value) MustDurationSlices(opts ...Option[time.Duration]) [][]time.Duration {
+	return must(v.DurationSlices(opts...))
+}
+
+// IntSlices returns [][]int
+//
+//	NewValue("1,2;3,4").IntSlices() // [][]int{{1, 2}, {3, 4}}, nil
+//	NewValue("").IntSlices() // nil, error
+//	NewValue("1,a;3").IntSlices() // nil, error
+//	NewValue("1 2|3 4").IntSlices(WithDelimiter[int](" "), WithOuterDelimiter[int]("|")) // [][]int{{1, 2}, {3, 4}}, nil
+func (v Value) IntSlices(opts ...Option[int]) ([][]int, error) {
+	option := getOpts(opts).secretOf(v)
+	return get(v, option.getNestedDefault(), func(payload string) ([][]int, error) {
+		return toSlices(payload, option, strconv.Atoi)
+	})
+}
+
+// MustIntSlices returns [][]int if error not nil will be ignored
+//
+//	NewValue("1,2;3,4").MustIntSlices() // [][]int{{1, 2}, {3, 4}}
+//	NewValue("1,a;3").MustIntSlices() // nil
+func (v Value) MustIntSlices(opts ...Option[int]) [][]int {
+	return must(v.IntSlices(opts...))
+}
+
+// Int8Slices returns [][]int8
+//
+//	NewValue("1,2;3,4").Int8Slices() // [][]int8{{1, 2}, {3, 4}}, nil
+//	NewValue("").Int8Slices() // nil, error
+func (v Value) Int8Slices(opts ...Option[int8]) ([][]int8, error) {
+	option := getOpts(opts).secretOf(v)
+	return get(v, option.getNestedDefault(), func(payload string) ([][]int8, error) {
+		return toSlices(payload, option, parseInt8)
+	})
+}
+
+// MustInt8Slices returns [][]int8 if error not nil will be ignored
+//
+//	NewValue("1,2;3,4").MustInt8Slices() // [][]int8{{1, 2}, {3, 4}}
+//	NewValue("").MustInt8Slices() // nil
+func (v Value) MustInt8Slices(opts ...Option[int8]) [][]int8 {
+	return must(v.Int8Slices(opts...))
+}
+
+// Int16Slices returns [][]int16
+//
+//	NewValue("1,2;3,4").Int16Slices() // [][]int16{{1, 2}, {3, 4}}, nil
+//	NewValue("").Int16Slices() // nil, error
+func (v Value) Int16Slices(opts ...Option[int16]) ([][]int16, error) {
+	option := getOpts(opts).secretOf(v)
+	return get(v, option.getNestedDefault(), func(payload string) ([][]int16, error) {
+		return toSlices(payload, option, parseInt16)
+	})
+}
+
+// MustInt16Slices returns [][]int16 if error not nil will be ignored
+//
+//	NewValue("1,2;3,4").MustInt16Slices() // [][]int16{{1, 2}, {3, 4}}
+//	NewValue("").MustInt16Slices() // nil
+func (v Value) MustInt16Slices(opts ...Option[int16]) [][]int16 {
+	return must(v.Int16Slices(opts...))
+}
+
+// Int32Slices returns [][]int32
+//
+//	NewValue("1,2;3,4").Int32Slices() // [][]int32{{1, 2}, {3, 4}}, nil
+//	NewValue("").Int32Slices() // nil, error
+func (v Value) Int32Slices(opts ...Option[int32]) ([][]int32, error) {
+	option := getOpts(opts).secretOf(v)
+	return get(v, option.getNestedDefault(), func(payload string) ([][]int32, error) {
+		return toSlices(payload, option, parseInt32)
+	})
+}
+
+// MustInt32Slices returns [][]int32 if error not nil will be ignored
+//
+//	NewValue("1,2;3,4").MustInt32Slices() // [][]int32{{1, 2}, {3, 4}}
+//	NewValue("").MustInt32Slices() // nil
+func (v Value) MustInt32Slices(opts ...Option[int32]) [][]int32 {
+	return must(v.Int32Slices(opts...))
+}
+
+// Int64Slices returns [][]int64
+//
+//	NewValue("1,2;3,4").Int64Slices() // [][]int64{{1, 2}, {3, 4}}, nil
+//	NewValue("").Int64Slices() // nil, error
+func (v Value) Int64Slices(opts ...Option[int64]) ([][]int64, error) {
+	option := getOpts(opts).secretOf(v)
+	return get(v, option.getNestedDefault(), func(payload string) ([][]int64, error) {
+		return toSlices(payload, option, parseInt64)
+	})
+}
+
+// MustInt64Slices returns [][]int64 if error not nil will be ignored
+//
+//	NewValue("1,2;3,4").MustInt64Slices() // [][]int64{{1, 2}, {3, 4}}
+//	NewValue("").MustInt64Slices() // nil
+func (v Value) MustInt64Slices(opts ...Option[int64]) [][]int64 {
+	return must(v.Int64Slices(opts...))
+}
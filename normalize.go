@@ -0,0 +1,61 @@
+package argsx
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Normalizer converts a key to its canonical form, keys have the same canonical form are equivalent
+type Normalizer func(key string) string
+
+var (
+	// FoldCase makes keys case-insensitive: --DryRun equals --dryrun
+	FoldCase Normalizer = strings.ToLower
+
+	// FoldSeparators makes '-', '_' and '.' in keys equivalent: --dry_run equals --dry-run
+	FoldSeparators Normalizer = strings.NewReplacer("_", "-", ".", "-").Replace
+
+	// KebabCase converts camel case keys and separators to lower kebab case:
+	// --DryRun, --dry_run and --dry.run equal --dry-run
+	KebabCase Normalizer = kebabCase
+)
+
+// kebabCase converts key to lower case words separated by '-'
+func kebabCase(key string) string {
+	var sb strings.Builder
+	runes := []rune(key)
+	for idx, r := range runes {
+		switch {
+		case r == '_' || r == '.' || r == '-':
+			sb.WriteByte('-')
+		case unicode.IsUpper(r):
+			if idx > 0 && runes[idx-1] != '_' && runes[idx-1] != '.' && runes[idx-1] != '-' &&
+				(unicode.IsLower(runes[idx-1]) || unicode.IsDigit(runes[idx-1]) ||
+					(idx+1 < len(runes) && unicode.IsLower(runes[idx+1]))) {
+				sb.WriteByte('-')
+			}
+			sb.WriteRune(unicode.ToLower(r))
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// SetNormalizer specify normalizers applied in order to keys both in parsing and Fetch,
+// no normalizer means keys are compared exactly
+//
+//	SetNormalizer(FoldCase, FoldSeparators)
+//	SetNormalizer(KebabCase)
+func (x *Argsx) SetNormalizer(normalizers ...Normalizer) {
+	x.mux.Lock()
+	defer x.mux.Unlock()
+
+	x.resolver.normalizers = normalizers
+	x.reset()
+}
+
+// SetNormalizer specify normalizers of the default instance
+func SetNormalizer(normalizers ...Normalizer) {
+	dx.SetNormalizer(normalizers...)
+}
@@ -0,0 +1,37 @@
+package argsx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizer(t *testing.T) {
+	x := NewWithArgs([]string{"app", "--DryRun", "--log_level", "debug", "--HTTPServer.Port=8080"})
+	require.True(t, x.Fetch("DryRun").MustBool())
+	require.Equal(t, Value{}, x.Fetch("dry-run"))
+
+	x.SetNormalizer(FoldCase)
+	require.True(t, x.Fetch("dryrun").MustBool())
+	require.Equal(t, Value{}, x.Fetch("log-level"))
+
+	x.SetNormalizer(FoldCase, FoldSeparators)
+	require.Equal(t, "debug", x.Fetch("LOG-LEVEL").MustString())
+	require.Equal(t, 8080, x.Fetch("httpserver_port").MustInt())
+
+	x.SetNormalizer(KebabCase)
+	require.True(t, x.Fetch("dry_run").MustBool())
+	require.Equal(t, "debug", x.Fetch("LogLevel").MustString())
+	require.Equal(t, 8080, x.Fetch("http-server-port").MustInt())
+
+	for key, expected := range map[string]string{
+		"DryRun":     "dry-run",
+		"dry_run":    "dry-run",
+		"dry.run":    "dry-run",
+		"HTTPServer": "http-server",
+		"ipv6Addr":   "ipv6-addr",
+		"already-ok": "already-ok",
+	} {
+		require.Equal(t, expected, KebabCase(key))
+	}
+}
@@ -1,21 +1,48 @@
 package argsx
 
+import "time"
+
 type options[T any] struct {
-	delimiter string
-	defaultV  []T
+	delimiter      string
+	outerDelimiter string
+	delimiters     []string
+	pattern        string
+	whitespace     bool
+	quoted         bool
+	defaultV       []T
+	validators     []func(T) error
+	location       *time.Location
+	locationName   string
+	layouts        []string
+	parse          parser[T]
+	secret         bool
 }
 
 type Option[T any] func(*options[T])
 
-// getOpts parse option the default delimiter is ,
+// getOpts parse option the default delimiter is , and the default outer delimiter is ;
 func getOpts[T any](opts []Option[T]) *options[T] {
-	op := &options[T]{delimiter: ","}
+	op := &options[T]{delimiter: ",", outerDelimiter: ";"}
 	for _, opt := range opts {
 		opt(op)
 	}
 	return op
 }
 
+// secretOf marks the options are used by secret value v, the elements in errors are redacted
+func (opts *options[T]) secretOf(v Value) *options[T] {
+	opts.secret = v.secret
+	return opts
+}
+
+// getParser returns the parser specified by options otherwise the default parser
+func (opts options[T]) getParser(parse parser[T]) parser[T] {
+	if opts.parse != nil {
+		return opts.parse
+	}
+	return parse
+}
+
 // getDefault get the default value of T type
 func (opts options[T]) getDefault() (t [][]T) {
 	if opts.defaultV == nil {
@@ -32,6 +59,8 @@ func (opts options[T]) getDefault() (t [][]T) {
 func WithDelimiter[T any](delimiter string) Option[T] {
 	return func(opts *options[T]) {
 		opts.delimiter = delimiter
+		opts.delimiters = nil
+		opts.pattern = ""
 	}
 }
 
@@ -1,46 +1,117 @@
 package argsx
 
 import (
+	"errors"
+	"fmt"
+	"os"
 	"strings"
-	"sync/atomic"
 )
 
-// parseArgs parse os args to Value instance
-func (x *Argsx) parseArgs() {
-	if atomic.LoadUint32(&x.done) == 1 {
-		return
+// parse parses args to a new snapshot, the caller must hold x.mux
+func (x *Argsx) parse() *Snapshot {
+	s := &Snapshot{
+		values:   make(map[string]Value),
+		flags:    append([]Flag(nil), x.flags...),
+		resolver: x.resolver.clone(),
 	}
 
-	x.mux.Lock()
-	defer x.mux.Unlock()
-
-	if x.done == 1 {
-		return
+	takes := s.takesValue()
+	args, files, err := x.expandArgs(takes)
+	if err != nil {
+		args = x.args
 	}
+	s.files = files
 
+	var order []string
 	idx := 1
 	for {
-		key, val := x.getKV(&idx)
+		key, val := getKV(args, &idx, takes)
 		if key == "" && val == "" {
 			break
 		}
 
-		ck := strings.Trim(key, "-")
-		x.values[ck] = Value{key, val}
+		ck := s.resolver.canonical(strings.Trim(key, "-"))
+		value := Value{fullkey: key, payload: val}
+		if prev, ok := s.values[ck]; ok && prev.fullkey != key && prev.payload != val {
+			prevPayload, payload := prev.payload, val
+			if s.isSecret(ck) {
+				prevPayload, payload = redacted, redacted
+			}
+			err = errors.Join(err, fmt.Errorf("conflicting values for key: `%s`, `%s=%s` and `%s=%s`",
+				dash(ck), prev.fullkey, prevPayload, key, payload))
+		} else if !ok {
+			order = append(order, ck)
+		}
+		s.values[ck] = value
 	}
+	err = errors.Join(err, x.rejectExperimental(s))
+	s.migrateDeprecated()
+	s.err = errors.Join(err, x.resolveSecrets(s, s.keysOf(x.args, takes)))
 
-	atomic.StoreUint32(&x.done, 1)
+	s.defaulted = make(map[string]bool)
+	for _, flag := range s.flags {
+		ck := s.resolver.canonical(flag.Name)
+		if _, ok := s.values[ck]; ok {
+			continue
+		}
+		if payload, ok := x.prompted[ck]; ok {
+			s.values[ck] = Value{fullkey: flag.dash(), payload: payload, secret: flag.Secret}
+		} else if env, ok := os.LookupEnv(flag.Env); ok && len(flag.Env) > 0 {
+			if flag.Visibility == Experimental && !s.experimentalEnabled() {
+				s.err = errors.Join(s.err, x.experimental(s, fmt.Sprintf("env `$%s` of flag `%s`", flag.Env, flag.dash())))
+				continue
+			}
+			s.values[ck] = Value{fullkey: flag.dash(), payload: env, secret: flag.Secret}
+		} else if len(flag.Default) > 0 {
+			s.values[ck] = Value{fullkey: flag.dash(), payload: flag.Default, secret: flag.Secret}
+			s.defaulted[ck] = true
+		} else {
+			continue
+		}
+		order = append(order, ck)
+	}
+	s.order = s.ordered(order)
+	s.err = errors.Join(s.err, s.check(x.strict))
+	return s
 }
 
-// getKV returns key value pair the key has prefix '-' value is original
-func (x *Argsx) getKV(idx *int) (string, string) {
-	v := x.next(idx)
+// takesValue returns a function reports whether key takes the next argument as its value:
+// registered bool flags are switches take a value only by `=`, a lone `-` is the value of
+// secret flags and their file keys only, and other arguments start with '-' are keys
+func (s *Snapshot) takesValue() func(key, next string) bool {
+	switches := map[string]bool{s.resolver.canonical(enableExperimental): true}
+	stdin := make(map[string]bool)
+	for _, flag := range s.flags {
+		ck := s.resolver.canonical(flag.Name)
+		switches[ck] = flag.isBool()
+		if flag.Secret {
+			stdin[ck] = true
+			stdin[s.resolver.canonical(flag.Name+secretFileSuffix)] = true
+		}
+	}
+	return func(key, next string) bool {
+		ck := s.resolver.canonical(strings.Trim(key, "-"))
+		switch {
+		case switches[ck]:
+			return false
+		case next == stdinPayload:
+			return stdin[ck]
+		default:
+			return !strings.HasPrefix(next, "-")
+		}
+	}
+}
+
+// getKV returns key value pair the key has prefix '-' value is original,
+// the next argument is the value of key when takes reports true
+func getKV(args []string, idx *int, takes func(key, next string) bool) (string, string) {
+	v := next(args, idx)
 	if len(v) == 0 {
 		return "", ""
 	}
 
 	if !strings.HasPrefix(v, "-") {
-		return x.getKV(idx)
+		return getKV(args, idx, takes)
 	}
 
 	var key, value string
@@ -50,23 +121,20 @@ func (x *Argsx) getKV(idx *int) (string, string) {
 		value = arr[1]
 	} else {
 		key = v
-		v = x.next(idx)
-		if !strings.HasPrefix(v, "-") {
-			value = v
-		} else {
-			*idx -= 1
+		if *idx < len(args) && takes(key, args[*idx]) {
+			value = next(args, idx)
 		}
 	}
 	return key, value
 }
 
-// next get os args next value
-func (x *Argsx) next(idx *int) string {
-	if len(x.args)-1 < *idx {
+// next get args next value
+func next(args []string, idx *int) string {
+	if len(args)-1 < *idx {
 		return ""
 	}
 
-	key := x.args[*idx]
+	key := args[*idx]
 	*idx += 1
 	return key
 }
@@ -0,0 +1,164 @@
+package argsx
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+)
+
+// promptAttempts is the max attempts of asking a value before giving up
+const promptAttempts = 3
+
+// Prompt asks for the required flags missing after parsing on r and w, choices are
+// shown as a menu and invalid answers are asked again. The input of secret flags is hidden
+// on terminal, it's an error when the echo can't be disabled. Prompting is disabled when r
+// is a file but not a terminal like piped stdin, the answers are kept in later parsing
+//
+//	Register(Flag{Name: "region", Required: true, Choices: []string{"us", "eu"}})
+//	if err := Prompt(os.Stdin, os.Stderr); err != nil {
+//		log.Fatal(err)
+//	}
+func (x *Argsx) Prompt(r io.Reader, w io.Writer) error {
+	if f, ok := r.(*os.File); ok && !isTerminal(f) {
+		return x.Err()
+	}
+
+	s := x.Snapshot()
+	br := bufio.NewReader(r)
+	answers := make(map[string]string)
+	for _, flag := range s.flags {
+		ck := s.resolver.canonical(flag.Name)
+		if _, ok := s.values[ck]; ok || !flag.Required {
+			continue
+		}
+
+		payload, err := flag.ask(br, r, w)
+		if err != nil {
+			return fmt.Errorf("prompt `%s`: %w", flag.dash(), err)
+		}
+		answers[ck] = payload
+	}
+	if len(answers) == 0 {
+		return s.err
+	}
+
+	x.mux.Lock()
+	defer x.mux.Unlock()
+	if x.prompted == nil {
+		x.prompted = make(map[string]string)
+	}
+	for key, payload := range answers {
+		x.prompted[key] = payload
+	}
+	s = x.parse()
+	x.store(s)
+	return s.err
+}
+
+// ask reads the value of flag from br until it's valid, r is the underlying reader to hide the input
+func (f Flag) ask(br *bufio.Reader, r io.Reader, w io.Writer) (string, error) {
+	label := f.Name
+	if len(f.Usage) > 0 {
+		label = f.Usage
+	}
+	for attempt := 1; ; attempt++ {
+		if len(f.Choices) > 0 {
+			fmt.Fprintf(w, "%s (%s):\n", label, f.dash())
+			for i, choice := range f.Choices {
+				fmt.Fprintf(w, "  %d) %s\n", i+1, choice)
+			}
+			fmt.Fprint(w, "choose: ")
+		} else {
+			fmt.Fprintf(w, "%s (%s): ", label, f.dash())
+		}
+
+		payload, err := readAnswer(br, r, f.Secret)
+		if f.Secret {
+			fmt.Fprintln(w)
+		}
+		if err != nil && (!errors.Is(err, io.EOF) || len(payload) == 0) {
+			return "", err
+		}
+		if n, err := strconv.Atoi(payload); err == nil && len(f.Choices) > 0 && n > 0 && n <= len(f.Choices) {
+			payload = f.Choices[n-1]
+		}
+
+		if len(payload) == 0 {
+			err = errors.New("value required")
+		} else {
+			err = f.check(Value{fullkey: f.dash(), payload: payload, secret: f.Secret})
+		}
+		if err == nil {
+			return payload, nil
+		}
+		if attempt == promptAttempts {
+			return "", err
+		}
+		fmt.Fprintf(w, "invalid value: %s, try again\n", err)
+	}
+}
+
+// readAnswer reads a line from br, the echo of terminal is disabled when hidden and
+// it's an error when the echo can't be disabled, the echo is restored on interrupt
+func readAnswer(br *bufio.Reader, r io.Reader, hidden bool) (string, error) {
+	if f, ok := r.(*os.File); ok && hidden && isTerminal(f) {
+		if err := setEcho(f, false); err != nil {
+			return "", fmt.Errorf("can't hide the input of secret: %w", err)
+		}
+		defer restoreEchoOnInterrupt(f)()
+	}
+	line, err := br.ReadString('\n')
+	return strings.TrimRight(line, "\r\n"), err
+}
+
+// restoreEchoOnInterrupt restores the echo of terminal f and raises the signal again when
+// the process is interrupted, the returned function restores the echo and stops watching
+func restoreEchoOnInterrupt(f *os.File) func() {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, os.Interrupt)
+	go func() {
+		select {
+		case sig := <-signals:
+			_ = setEcho(f, true)
+			signal.Stop(signals)
+			if p, err := os.FindProcess(os.Getpid()); err == nil {
+				_ = p.Signal(sig)
+			}
+		case <-done:
+		}
+	}()
+	return func() {
+		signal.Stop(signals)
+		close(done)
+		_ = setEcho(f, true)
+	}
+}
+
+// isTerminal reports whether f is a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// setEcho turns on or off the echo of terminal f by stty
+func setEcho(f *os.File, on bool) error {
+	mode := "-echo"
+	if on {
+		mode = "echo"
+	}
+	cmd := exec.Command("stty", mode)
+	cmd.Stdin = f
+	return cmd.Run()
+}
+
+// Prompt asks for the required flags missing of the default instance
+func Prompt(r io.Reader, w io.Writer) error {
+	return dx.Prompt(r, w)
+}
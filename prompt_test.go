@@ -0,0 +1,63 @@
+package argsx
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrompt(t *testing.T) {
+	x := NewWithArgs([]string{"app", "--user", "root"})
+	x.Register(
+		Flag{Name: "region", Usage: "deploy region", Required: true, Choices: []string{"us", "eu"}},
+		Flag{Name: "replicas", Type: "int", Required: true},
+		Flag{Name: "token", Required: true, Secret: true},
+		Flag{Name: "user", Required: true},
+	)
+	require.Error(t, x.Err())
+
+	out := new(bytes.Buffer)
+	require.NoError(t, x.Prompt(strings.NewReader("asia\n2\nthree\n3\ns3cret\n"), out))
+	require.Equal(t, "eu", x.Fetch("region").MustString())
+	require.Equal(t, 3, x.Fetch("replicas").MustInt())
+	require.Equal(t, "s3cret", x.Fetch("token").MustReveal())
+	require.Equal(t, "root", x.Fetch("user").MustString())
+	require.Equal(t, "deploy region (--region):\n  1) us\n  2) eu\nchoose: "+
+		"invalid value: must be one of [us eu], try again\n"+
+		"deploy region (--region):\n  1) us\n  2) eu\nchoose: "+
+		"replicas (--replicas): invalid value: not a valid int, try again\n"+
+		"replicas (--replicas): token (--token): \n", out.String())
+
+	// answers are kept in later parsing
+	require.NoError(t, x.Parse())
+	require.Equal(t, "eu", x.Fetch("region").MustString())
+
+	y := NewWithArgs([]string{"app"})
+	y.Register(Flag{Name: "port", Type: "int", Required: true})
+	err := y.Prompt(strings.NewReader("a\nb\nc\nd\n"), new(bytes.Buffer))
+	require.EqualError(t, err, "prompt `--port`: not a valid int")
+
+	err = y.Prompt(strings.NewReader(""), new(bytes.Buffer))
+	require.EqualError(t, err, "prompt `--port`: EOF")
+
+	// not a terminal
+	file, err := os.Create(filepath.Join(t.TempDir(), "stdin"))
+	require.NoError(t, err)
+	defer file.Close()
+	err = y.Prompt(file, new(bytes.Buffer))
+	require.EqualError(t, err, "required key not specified: `--port`")
+
+	// the secret is not read when the echo can't be disabled, /dev/null is a char device but stty fails on it
+	devNull, err := os.Open(os.DevNull)
+	require.NoError(t, err)
+	defer devNull.Close()
+	if isTerminal(devNull) {
+		_, err = readAnswer(bufio.NewReader(strings.NewReader("hunter2\n")), devNull, true)
+		require.ErrorContains(t, err, "can't hide the input of secret")
+	}
+}
@@ -0,0 +1,87 @@
+package argsx
+
+import "sort"
+
+// ordered returns the keys of values in order, the keys not in order like the
+// replacements of deprecated flags are appended in lexical order
+func (s *Snapshot) ordered(order []string) []string {
+	keys := make([]string, 0, len(s.values))
+	seen := make(map[string]bool, len(s.values))
+	for _, key := range order {
+		if _, ok := s.values[key]; ok && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	var rest []string
+	for key := range s.values {
+		if !seen[key] {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	return append(keys, rest...)
+}
+
+// Keys returns the canonical keys of values in args order, followed by the
+// registered flags filled from env or default
+//
+//	os.Args = []string{"app", "--port", "8080", "-v"}
+//	Keys() // ["port", "v"]
+func (s *Snapshot) Keys() []string {
+	return append([]string(nil), s.order...)
+}
+
+// All returns the values in the order of Keys
+func (s *Snapshot) All() []Value {
+	values := make([]Value, 0, len(s.order))
+	for _, key := range s.order {
+		values = append(values, s.values[key])
+	}
+	return values
+}
+
+// Range calls fn for each key and value in the order of Keys until fn returns false
+//
+//	Range(func(key string, v Value) bool {
+//		log.Printf("%s=%s", v.Key(), v)
+//		return true
+//	})
+func (s *Snapshot) Range(fn func(key string, v Value) bool) {
+	for _, key := range s.order {
+		if !fn(key, s.values[key]) {
+			return
+		}
+	}
+}
+
+// Keys returns the canonical keys of values in args order
+func (x *Argsx) Keys() []string {
+	return x.Snapshot().Keys()
+}
+
+// All returns the values in args order
+func (x *Argsx) All() []Value {
+	return x.Snapshot().All()
+}
+
+// Range calls fn for each key and value in args order until fn returns false
+func (x *Argsx) Range(fn func(key string, v Value) bool) {
+	x.Snapshot().Range(fn)
+}
+
+// Keys returns the canonical keys of values of the default instance in args order
+func Keys() []string {
+	return dx.Keys()
+}
+
+// All returns the values of the default instance in args order
+func All() []Value {
+	return dx.All()
+}
+
+// Range calls fn for each key and value of the default instance in args order until fn returns false
+func Range(fn func(key string, v Value) bool) {
+	dx.Range(fn)
+}
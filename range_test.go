@@ -0,0 +1,37 @@
+package argsx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRange(t *testing.T) {
+	t.Setenv("ARGSX_RANGE_REGION", "eu")
+	x := NewWithArgs([]string{"app", "--zone", "a", "-v", "--port=8080", "--zone", "b", "--token", "s3cret", "--old", "x"})
+	x.SetWarnWriter(nil)
+	x.Register(
+		Flag{Name: "region", Env: "ARGSX_RANGE_REGION"},
+		Flag{Name: "format", Default: "json"},
+		Flag{Name: "token", Secret: true},
+		Flag{Name: "old", Deprecated: "renamed", ReplacedBy: "new"},
+	)
+
+	require.Equal(t, []string{"zone", "v", "port", "token", "old", "region", "format", "new"}, x.Keys())
+
+	values := x.All()
+	require.Len(t, values, 8)
+	require.Equal(t, "--zone", values[0].Key())
+	require.Equal(t, "b", values[0].Raw())
+	require.Equal(t, "", values[1].Raw())
+	require.Equal(t, "[REDACTED]", values[3].Raw())
+	require.Equal(t, "--format", values[6].Key())
+	require.Equal(t, "json", values[6].Raw())
+
+	var keys []string
+	x.Range(func(key string, v Value) bool {
+		keys = append(keys, key+"="+v.Raw())
+		return key != "port"
+	})
+	require.Equal(t, []string{"zone=b", "v=", "port=8080"}, keys)
+}
@@ -0,0 +1,200 @@
+package argsx
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// flagfilePrefix is the gflags style key of a flag file
+const flagfilePrefix = "--flagfile"
+
+// expandArgs returns args with response files and flag files expanded:
+//
+//	@path            the file contains shell-quoted tokens, # starts a comment
+//	--flagfile=path  the file contains one argument per line, blank lines and lines start with # are skipped
+//	--flagfile path  same as --flagfile=path
+//	@@text           the literal argument @text in any position, not a file
+//
+// The @path is expanded only in key position, the value of a key like `--mention @alice` is kept,
+// takes reports whether a key takes the next argument as its value.
+// Files may include other files, relative paths in a file are resolved from its directory.
+// A response file not exists is kept as the literal argument
+// The files read are returned for watching, the caller must hold x.mux
+func (x *Argsx) expandArgs(takes func(key, next string) bool) ([]string, []string, error) {
+	if len(x.args) == 0 {
+		return nil, nil, nil
+	}
+	var files []string
+	expanded, err := expand(x.args[1:], "", nil, &files, takes)
+	if err != nil {
+		return nil, files, err
+	}
+	return append([]string{x.args[0]}, expanded...), files, nil
+}
+
+// expand expands args read from dir, stack is the chain of including files for cycle detection,
+// the paths of files read are appended to files
+func expand(args []string, dir string, stack []string, files *[]string, takes func(key, next string) bool) ([]string, error) {
+	var (
+		expanded []string
+		key      string // the last key may take the next argument as its value
+	)
+	for idx := 0; idx < len(args); idx++ {
+		arg := args[idx]
+		if strings.HasPrefix(arg, "@@") {
+			key = ""
+			expanded = append(expanded, arg[1:])
+			continue
+		}
+		if len(key) > 0 && takes(key, arg) {
+			key = ""
+			expanded = append(expanded, arg)
+			continue
+		}
+		key = ""
+
+		var (
+			path     string
+			perLine  bool
+			optional bool
+		)
+		switch {
+		case strings.HasPrefix(arg, "@") && len(arg) > 1:
+			path, optional = arg[1:], true
+		case strings.HasPrefix(arg, flagfilePrefix+"="):
+			path, perLine = arg[len(flagfilePrefix)+1:], true
+		case arg == flagfilePrefix && idx+1 < len(args):
+			idx++
+			path, perLine = args[idx], true
+		default:
+			if strings.HasPrefix(arg, "-") && !strings.Contains(arg, "=") {
+				key = arg
+			}
+			expanded = append(expanded, arg)
+			continue
+		}
+
+		if !filepath.IsAbs(path) && len(dir) > 0 {
+			path = filepath.Join(dir, path)
+		}
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, including := range stack {
+			if including == abs {
+				return nil, fmt.Errorf("cyclic include of args file: `%s`", path)
+			}
+		}
+
+		data, err := os.ReadFile(abs)
+		if err != nil {
+			if optional && errors.Is(err, os.ErrNotExist) {
+				expanded = append(expanded, arg)
+				continue
+			}
+			return nil, fmt.Errorf("read args file: %w", err)
+		}
+		*files = append(*files, abs)
+
+		var tokens []string
+		if perLine {
+			tokens = splitLines(string(data))
+		} else if tokens, err = splitShell(string(data)); err != nil {
+			return nil, fmt.Errorf("args file `%s`: %w", path, err)
+		}
+
+		tokens, err = expand(tokens, filepath.Dir(abs), append(stack, abs), files, takes)
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, tokens...)
+	}
+	return expanded, nil
+}
+
+// splitLines returns the lines of s as arguments, blank lines and comments are skipped
+func splitLines(s string) []string {
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// splitShell splits s into tokens like POSIX shell: whitespaces separate tokens,
+// single quotes preserve literal, double quotes and backslashes escape characters,
+// and # at the beginning of a token starts a comment till the end of line
+func splitShell(s string) ([]string, error) {
+	var (
+		tokens  []string
+		token   strings.Builder
+		inToken bool
+	)
+	runes := []rune(s)
+	for idx := 0; idx < len(runes); idx++ {
+		r := runes[idx]
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inToken {
+				tokens = append(tokens, token.String())
+				token.Reset()
+				inToken = false
+			}
+		case r == '#' && !inToken:
+			for idx < len(runes) && runes[idx] != '\n' {
+				idx++
+			}
+		case r == '\\':
+			idx++
+			if idx == len(runes) {
+				return nil, errors.New("trailing backslash")
+			}
+			if runes[idx] != '\n' {
+				token.WriteRune(runes[idx])
+				inToken = true
+			}
+		case r == '\'':
+			end := idx + 1
+			for end < len(runes) && runes[end] != '\'' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, errors.New("unterminated single quote")
+			}
+			token.WriteString(string(runes[idx+1 : end]))
+			idx, inToken = end, true
+		case r == '"':
+			idx++
+			for ; idx < len(runes) && runes[idx] != '"'; idx++ {
+				if runes[idx] == '\\' && idx+1 < len(runes) && strings.ContainsRune("\"\\$`\n", runes[idx+1]) {
+					idx++
+					if runes[idx] == '\n' {
+						continue
+					}
+				}
+				token.WriteRune(runes[idx])
+			}
+			if idx == len(runes) {
+				return nil, errors.New("unterminated double quote")
+			}
+			inToken = true
+		default:
+			token.WriteRune(r)
+			inToken = true
+		}
+	}
+	if inToken {
+		tokens = append(tokens, token.String())
+	}
+	return tokens, nil
+}
@@ -0,0 +1,57 @@
+package argsx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "nested"), 0o755))
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+
+	write("nested/flags.txt", "# flag file\n--region=us east\n\n--replicas=3\n")
+	args := write("args.txt", `--name "John \"J\" Smith" # comment
+--path 'C:\Program Files' --flagfile=nested/flags.txt
+--multi line\
+d`)
+
+	x := NewWithArgs([]string{"app", "@" + args, "--mention", "@nobody", "--after", "1"})
+	require.NoError(t, x.Err())
+	require.Equal(t, `John "J" Smith`, x.Fetch("name").MustString())
+	require.Equal(t, `C:\Program Files`, x.Fetch("path").MustString())
+	require.Equal(t, "us east", x.Fetch("region").MustString())
+	require.Equal(t, 3, x.Fetch("replicas").MustInt())
+	require.Equal(t, "lined", x.Fetch("multi").MustString())
+	require.Equal(t, "@nobody", x.Fetch("mention").MustString())
+	require.Equal(t, 1, x.Fetch("after").MustInt())
+
+	x.SetArgs([]string{"app", "--flagfile", filepath.Join(dir, "missing.txt")})
+	require.Error(t, x.Err())
+
+	write("a.txt", "@b.txt")
+	write("b.txt", "--flagfile=a.txt")
+	x.SetArgs([]string{"app", "@" + filepath.Join(dir, "a.txt")})
+	require.ErrorContains(t, x.Err(), "cyclic include")
+
+	// only keys position expands, @@ escapes
+	admin := write("admin.txt", "--admin")
+	x.Register(Flag{Name: "verbose", Type: "bool"})
+	x.SetArgs([]string{"app", "--mention", "@" + admin, "--verbose", "@" + admin, "--escaped", "@@" + admin})
+	require.NoError(t, x.Err())
+	require.Equal(t, "@"+admin, x.Fetch("mention").MustString())
+	require.Equal(t, "", x.Fetch("verbose").MustString())
+	require.Equal(t, "@"+admin, x.Fetch("escaped").MustString())
+	require.Equal(t, []string{"mention", "verbose", "admin", "escaped"}, x.Keys())
+
+	write("quote.txt", `--name "unterminated`)
+	x.SetArgs([]string{"app", "@" + filepath.Join(dir, "quote.txt")})
+	require.ErrorContains(t, x.Err(), "unterminated double quote")
+}
@@ -0,0 +1,227 @@
+package argsx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// secretFileSuffix is appended to the key of secret flag to read the payload from file
+const secretFileSuffix = "-file"
+
+// stdinPayload is the payload means reading from stdin
+const stdinPayload = "-"
+
+// isSecret reports whether the canonical key ck is a registered secret flag
+func (s *Snapshot) isSecret(ck string) bool {
+	for _, flag := range s.flags {
+		if flag.Secret && s.resolver.canonical(flag.Name) == ck {
+			return true
+		}
+	}
+	return false
+}
+
+// keysOf returns the canonical keys specified in args without expanding response files
+func (s *Snapshot) keysOf(args []string, takes func(key, next string) bool) map[string]bool {
+	keys := make(map[string]bool)
+	for idx := 1; ; {
+		key, val := getKV(args, &idx, takes)
+		if key == "" && val == "" {
+			return keys
+		}
+		keys[s.resolver.canonical(strings.Trim(key, "-"))] = true
+	}
+}
+
+// resolveSecrets reads the payload of secret flags from `--key-file path` or stdin when
+// the payload is `-`, marks the values secret and warns when a secret is specified in argv,
+// the keys of argv are inArgs and the secrets from response files are not warned
+func (x *Argsx) resolveSecrets(s *Snapshot, inArgs map[string]bool) error {
+	var errs []error
+	for _, flag := range s.flags {
+		if !flag.Secret {
+			continue
+		}
+
+		ck := s.resolver.canonical(flag.Name)
+		fileKey := s.resolver.canonical(flag.Name + secretFileSuffix)
+		value, ok := s.values[ck]
+		switch file, fromFile := s.values[fileKey]; {
+		case ok && value.payload == stdinPayload:
+			payload, err := x.readSecret(stdinPayload)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("read secret `%s` from stdin: %w", value.fullkey, err))
+			}
+			value.payload = payload
+		case ok:
+			if inArgs[ck] {
+				s.warnf("secret `%s` is specified in args and visible in process list, use `%s` instead",
+					value.fullkey, dash(flag.Name+secretFileSuffix))
+			}
+		case fromFile:
+			payload, err := x.readSecret(file.payload)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("read secret `%s` from `%s`: %w", flag.dash(), file.payload, err))
+			}
+			value, ok = Value{fullkey: file.fullkey, payload: payload}, true
+		}
+		delete(s.values, fileKey)
+
+		if ok {
+			value.secret = true
+			s.values[ck] = value
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// readSecret reads the secret from file or stdin when path is `-`, the trailing line break is trimmed.
+// Stdin is read once and reused in later parsing
+func (x *Argsx) readSecret(path string) (string, error) {
+	var data []byte
+	if path == stdinPayload {
+		if x.stdinSecret == nil {
+			stdin := x.stdin
+			if stdin == nil {
+				stdin = os.Stdin
+			}
+			bs, err := io.ReadAll(stdin)
+			if err != nil {
+				return "", err
+			}
+			x.stdinSecret = bs
+		}
+		data = x.stdinSecret
+	} else {
+		bs, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		data = bs
+	}
+	data = bytes.TrimSuffix(data, []byte("\n"))
+	data = bytes.TrimSuffix(data, []byte("\r"))
+	return string(data), nil
+}
+
+// SetWarnWriter specify the writer of warnings like secrets specified in args, default is os.Stderr,
+// nil discards warnings. A warning is written once until args changed by SetArgs
+func (x *Argsx) SetWarnWriter(w io.Writer) {
+	x.mux.Lock()
+	defer x.mux.Unlock()
+	if w == nil {
+		w = io.Discard
+	}
+	x.warn = w
+}
+
+// SetWarnWriter specify the writer of warnings of the default instance
+func SetWarnWriter(w io.Writer) {
+	dx.SetWarnWriter(w)
+}
+
+// redactedError hides the secret in the message of the wrapped error
+type redactedError struct {
+	err    error
+	secret string
+}
+
+// redactError returns err with secret in its message redacted
+func redactError(err error, secret string) error {
+	if len(secret) == 0 {
+		return err
+	}
+	return &redactedError{err: err, secret: secret}
+}
+
+func (e *redactedError) Error() string {
+	return strings.ReplaceAll(e.err.Error(), e.secret, redacted)
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// IsSecret reports whether the value belongs to a secret flag
+func (v Value) IsSecret() bool {
+	return v.secret
+}
+
+// Reveal returns the payload of secret value, String of secret value is redacted
+//
+//	Fetch("db-password").String() // "[REDACTED]", nil
+//	Fetch("db-password").Reveal() // "p@ssw0rd", nil
+func (v Value) Reveal(dv ...string) (string, error) {
+	return get(v, dv, func(payload string) (string, error) {
+		return payload, nil
+	})
+}
+
+// MustReveal returns the payload of secret value ignore error
+func (v Value) MustReveal(dv ...string) string {
+	return must(v.Reveal(dv...))
+}
+
+// RevealBytes returns a copy of the payload of secret value owned by the caller,
+// zero it by Wipe after use
+//
+//	password, err := Fetch("db-password").RevealBytes()
+//	defer Wipe(password)
+func (v Value) RevealBytes() ([]byte, error) {
+	payload, err := v.Reveal()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(payload), nil
+}
+
+// Wipe zeroes b, like the secret returned by RevealBytes
+func Wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// WipeSecrets zeroes and drops the secret read from stdin and the prompted secrets kept for
+// later parsing, the secret of `-` is read from stdin again by later parsing. The payloads
+// of parsed values are strings and can not be wiped, use RevealBytes to get a wipeable copy
+func (x *Argsx) WipeSecrets() {
+	x.mux.Lock()
+	defer x.mux.Unlock()
+
+	Wipe(x.stdinSecret)
+	x.stdinSecret = nil
+	for _, flag := range x.flags {
+		if flag.Secret {
+			delete(x.prompted, x.resolver.canonical(flag.Name))
+		}
+	}
+}
+
+// WipeSecrets zeroes and drops the secrets kept by the default instance
+func WipeSecrets() {
+	dx.WipeSecrets()
+}
+
+// Format implements fmt.Formatter, the payload of secret value is redacted
+func (v Value) Format(f fmt.State, verb rune) {
+	payload := v.payload
+	if v.secret {
+		payload = redacted
+	}
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprintf(f, "argsx.Value{fullkey:%s, payload:%s}", strconv.Quote(v.fullkey), strconv.Quote(payload))
+	case verb == 'v' && f.Flag('+'):
+		fmt.Fprintf(f, "{fullkey:%s payload:%s}", v.fullkey, payload)
+	case verb == 'q':
+		fmt.Fprint(f, strconv.Quote(payload))
+	default:
+		fmt.Fprintf(f, "{%s %s}", v.fullkey, payload)
+	}
+}
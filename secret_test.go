@@ -0,0 +1,106 @@
+package argsx
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecret(t *testing.T) {
+	warnings := new(bytes.Buffer)
+	x := NewWithArgs([]string{"app", "--db-password", "p@ss", "--user", "root"})
+	x.SetWarnWriter(warnings)
+	x.Register(Flag{Name: "db-password", Secret: true})
+
+	value := x.Fetch("db-password")
+	require.True(t, value.IsSecret())
+	require.Equal(t, "[REDACTED]", value.MustString())
+	require.Equal(t, "p@ss", value.MustReveal())
+	require.Equal(t, "{--db-password [REDACTED]}", fmt.Sprint(value))
+	require.Equal(t, `argsx.Value{fullkey:"--db-password", payload:"[REDACTED]"}`, fmt.Sprintf("%#v", value))
+	require.Equal(t, "{--user root}", fmt.Sprintf("%v", x.Fetch("user")))
+	require.Equal(t, "warning: secret `--db-password` is specified in args and visible in process list, use `--db-password-file` instead\n", warnings.String())
+
+	require.Equal(t, []string{"[REDACTED]"}, value.MustStringSlice())
+	require.Equal(t, [][]string{{"[REDACTED]"}}, value.MustStringSlices())
+	require.Equal(t, "[REDACTED]", value.MustEnum([]string{"p@ss"}))
+	_, err := value.Enum([]string{"a", "b"})
+	require.EqualError(t, err, "invalid value `[REDACTED]` for key: `--db-password`, must be one of [a, b]")
+
+	warnings.Reset()
+	file := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(file, []byte("from file\n"), 0o600))
+	x.SetStrict(true)
+	x.SetArgs([]string{"app", "--db-password-file", file})
+	require.NoError(t, x.Parse())
+	require.Equal(t, "from file", x.Fetch("db-password").MustReveal())
+	require.Equal(t, Value{}, x.Fetch("db-password-file"))
+	require.Empty(t, warnings.String())
+
+	x.stdin = strings.NewReader("from stdin\n")
+	x.SetArgs([]string{"app", "--db-password", "-"})
+	require.NoError(t, x.Parse())
+	require.Equal(t, "from stdin", x.Fetch("db-password").MustReveal())
+	require.NoError(t, x.Parse())
+	require.Equal(t, "from stdin", x.Fetch("db-password").MustReveal())
+
+	password, err := x.Fetch("db-password").RevealBytes()
+	require.NoError(t, err)
+	require.Equal(t, []byte("from stdin"), password)
+	Wipe(password)
+	require.Equal(t, make([]byte, len("from stdin")), password)
+	require.Equal(t, "from stdin", x.Fetch("db-password").MustReveal())
+
+	// the cached stdin is zeroed and read again
+	cached := x.stdinSecret
+	x.WipeSecrets()
+	require.Nil(t, x.stdinSecret)
+	require.Equal(t, make([]byte, len(cached)), cached)
+	x.stdin = strings.NewReader("stdin again\n")
+	require.NoError(t, x.Parse())
+	require.Equal(t, "stdin again", x.Fetch("db-password").MustReveal())
+
+	x.SetArgs([]string{"app", "--db-password-file", filepath.Join(t.TempDir(), "missing")})
+	require.Error(t, x.Parse())
+
+	// `-` is a key of other flags
+	x.SetStrict(false)
+	x.SetArgs([]string{"app", "--other", "-", "--db-password", "-"})
+	require.NoError(t, x.Parse())
+	require.Equal(t, "", x.Fetch("other").MustString())
+	require.Equal(t, "stdin again", x.Fetch("db-password").MustReveal())
+
+	// secrets from response files are not in process list
+	warnings.Reset()
+	x.SetArgs([]string{"app", "@" + writeFile(t, "args", "--db-password p@ss")})
+	require.Equal(t, "p@ss", x.Fetch("db-password").MustReveal())
+	require.Empty(t, warnings.String())
+
+	x.Register(Flag{Name: "port", Type: "int", Secret: true})
+	x.SetArgs([]string{"app", "--port-file", writeFile(t, "port", "80a")})
+	require.EqualError(t, x.Parse(), "invalid value `[REDACTED]` for key: `--port-file`: not a valid int")
+
+	// conflicts, trees and validator errors don't leak the secret
+	x.Alias("db-password", "pw")
+	x.SetArgs([]string{"app", "--db-password", "hunter2", "--pw", "hunter3"})
+	require.EqualError(t, x.Parse(), "conflicting values for key: `--db-password`, `--db-password=[REDACTED]` and `--pw=[REDACTED]`")
+	require.Equal(t, map[string]any{"db-password": "[REDACTED]"}, x.Tree())
+	value = x.Fetch("db-password")
+	_, err = value.StringSlice(WithPattern("^x$"))
+	require.NotContains(t, err.Error(), "hunter")
+	require.Contains(t, err.Error(), "[REDACTED]")
+	_, err = value.IntSlice()
+	require.NotContains(t, err.Error(), "hunter")
+}
+
+// writeFile writes content to the file name in a temporary directory and returns the path
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
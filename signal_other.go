@@ -0,0 +1,9 @@
+//go:build js
+
+package argsx
+
+import "os"
+
+// reloadSignals are the default signals of ReloadOnSignal, SIGHUP is not supported
+// on the platform and ReloadOnSignal needs explicit signals
+var reloadSignals []os.Signal
@@ -0,0 +1,11 @@
+//go:build !js
+
+package argsx
+
+import (
+	"os"
+	"syscall"
+)
+
+// reloadSignals are the default signals of ReloadOnSignal
+var reloadSignals = []os.Signal{syscall.SIGHUP}
@@ -0,0 +1,32 @@
+package argsx
+
+import "fmt"
+
+// Snapshot is the immutable result of a parsing, it's safe for concurrent use
+type Snapshot struct {
+	values map[string]Value
+	order  []string
+	flags  []Flag
+	files  []string
+	// defaulted are the keys filled by Flag.Default
+	defaulted map[string]bool
+	resolver  resolver
+	err       error
+	// warnings are written once by Argsx.store
+	warnings []string
+}
+
+// Fetch get the value by key
+func (s *Snapshot) Fetch(key string) Value {
+	return s.values[s.resolver.canonical(key)]
+}
+
+// warnf records a warning of the parsing
+func (s *Snapshot) warnf(format string, args ...any) {
+	s.warnings = append(s.warnings, fmt.Sprintf(format, args...))
+}
+
+// Err returns the error occurred in the parsing
+func (s *Snapshot) Err() error {
+	return s.err
+}
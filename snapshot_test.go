@@ -0,0 +1,49 @@
+package argsx
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	x := NewWithArgs([]string{"app", "--old", "1", "--port", "80"})
+	s := x.Snapshot()
+	require.Equal(t, 1, x.Fetch("old").MustInt())
+
+	x.SetArgs([]string{"app", "--port", "8080"})
+	require.Equal(t, Value{}, x.Fetch("old"))
+	require.Equal(t, 8080, x.Fetch("port").MustInt())
+	require.Equal(t, 80, s.Fetch("port").MustInt())
+	require.Equal(t, 1, s.Fetch("old").MustInt())
+
+	x.Alias("port", "p")
+	require.Equal(t, Value{}, s.Fetch("p"))
+	require.Equal(t, 8080, x.Fetch("p").MustInt())
+}
+
+func TestSnapshotConcurrent(t *testing.T) {
+	x := NewWithArgs([]string{"app", "--a", "0", "--b", "0"})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				n := strconv.Itoa(i*100 + j)
+				x.SetArgs([]string{"app", "--a", n, "--b", n})
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s := x.Snapshot()
+				require.Equal(t, s.Fetch("a").MustInt(), s.Fetch("b").MustInt())
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,179 @@
+package argsx
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// split splits payload into elements by the delimiters of options,
+// quotes and escapes are respected only when WithQuotes specified
+func (opts options[T]) split(payload string) ([]string, error) {
+	if !opts.quoted && !opts.whitespace && len(opts.pattern) == 0 && len(opts.delimiters) == 0 {
+		return strings.Split(payload, opts.delimiter), nil
+	}
+
+	match, err := opts.delimiterMatcher()
+	if err != nil {
+		return nil, err
+	}
+	if opts.whitespace {
+		payload = strings.TrimFunc(payload, unicode.IsSpace)
+	}
+
+	var (
+		elements []string
+		element  strings.Builder
+		quoted   bool
+	)
+	for idx := 0; idx < len(payload); {
+		c := payload[idx]
+		switch {
+		case opts.quoted && c == '\\':
+			if idx+1 == len(payload) {
+				return nil, errors.New("invalid slice value: trailing backslash")
+			}
+			_, size := utf8.DecodeRuneInString(payload[idx+1:])
+			element.WriteString(payload[idx+1 : idx+1+size])
+			idx += 1 + size
+			continue
+		case opts.quoted && c == '"':
+			if quoted && idx+1 < len(payload) && payload[idx+1] == '"' {
+				element.WriteByte('"')
+				idx += 2
+				continue
+			}
+			quoted = !quoted
+			idx++
+			continue
+		case !quoted:
+			if size := match(payload[idx:]); size > 0 {
+				elements = append(elements, element.String())
+				element.Reset()
+				idx += size
+				continue
+			}
+		}
+		element.WriteByte(c)
+		idx++
+	}
+	if quoted {
+		return nil, errors.New("invalid slice value: unterminated quote")
+	}
+	return append(elements, element.String()), nil
+}
+
+// splitRows splits payload into rows by the outer delimiter, with WithQuotes the delimiters
+// inside quotes or escaped are skipped and the rows are kept raw for split
+func (opts options[T]) splitRows(payload string) ([]string, error) {
+	if !opts.quoted || len(opts.outerDelimiter) == 0 {
+		return strings.Split(payload, opts.outerDelimiter), nil
+	}
+
+	var (
+		rows   []string
+		start  int
+		quoted bool
+	)
+	for idx := 0; idx < len(payload); {
+		switch c := payload[idx]; {
+		case c == '\\':
+			if idx+1 == len(payload) {
+				return nil, errors.New("invalid slice value: trailing backslash")
+			}
+			_, size := utf8.DecodeRuneInString(payload[idx+1:])
+			idx += 1 + size
+			continue
+		case c == '"':
+			quoted = !quoted
+		case !quoted && strings.HasPrefix(payload[idx:], opts.outerDelimiter):
+			rows = append(rows, payload[start:idx])
+			idx += len(opts.outerDelimiter)
+			start = idx
+			continue
+		}
+		idx++
+	}
+	if quoted {
+		return nil, errors.New("invalid slice value: unterminated quote")
+	}
+	return append(rows, payload[start:]), nil
+}
+
+// delimiterMatcher returns a function reports the length of delimiter at the beginning of s,
+// zero means s not starts with a delimiter
+func (opts options[T]) delimiterMatcher() (func(s string) int, error) {
+	switch {
+	case opts.whitespace:
+		return func(s string) int {
+			return len(s) - len(strings.TrimLeftFunc(s, unicode.IsSpace))
+		}, nil
+	case len(opts.pattern) > 0:
+		re, err := regexp.Compile(`^(?:` + opts.pattern + `)`)
+		if err != nil {
+			return nil, err
+		}
+		return func(s string) int {
+			if loc := re.FindStringIndex(s); loc != nil {
+				return loc[1]
+			}
+			return 0
+		}, nil
+	}
+
+	delimiters := opts.delimiters
+	if len(delimiters) == 0 {
+		delimiters = []string{opts.delimiter}
+	}
+	return func(s string) int {
+		size := 0
+		for _, delimiter := range delimiters {
+			if len(delimiter) > size && strings.HasPrefix(s, delimiter) {
+				size = len(delimiter)
+			}
+		}
+		return size
+	}, nil
+}
+
+// WithDelimiters specify multiple slice delimiters, any of them separates elements
+//
+//	WithDelimiters[string](",", ";")
+func WithDelimiters[T any](delimiters ...string) Option[T] {
+	return func(opts *options[T]) {
+		opts.delimiters = delimiters
+		opts.pattern = ""
+	}
+}
+
+// WithDelimiterPattern specify a regular expression matches the slice delimiter
+//
+//	WithDelimiterPattern[int](`\s*[,;]\s*`)
+func WithDelimiterPattern[T any](pattern string) Option[T] {
+	return func(opts *options[T]) {
+		opts.pattern = pattern
+		opts.delimiters = nil
+	}
+}
+
+// WithWhitespace splits slice elements by runs of whitespaces like strings.Fields
+//
+//	NewValue("a  b\tc").StringSlice(WithWhitespace[string]()) // []string{"a", "b", "c"}, nil
+func WithWhitespace[T any]() Option[T] {
+	return func(opts *options[T]) {
+		opts.whitespace = true
+	}
+}
+
+// WithQuotes enables CSV style quoting and backslash escapes in slice payload,
+// the delimiters inside double quotes are part of element, "" inside quotes is a literal quote
+//
+//	NewValue(`"a,b",c`).StringSlice(WithQuotes[string]()) // []string{"a,b", "c"}, nil
+//	NewValue(`a\,b,c`).StringSlice(WithQuotes[string]()) // []string{"a,b", "c"}, nil
+func WithQuotes[T any]() Option[T] {
+	return func(opts *options[T]) {
+		opts.quoted = true
+	}
+}
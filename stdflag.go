@@ -0,0 +1,121 @@
+package argsx
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+)
+
+// ImportFlagSet registers the flags defined in fs, so they appear in help, completion and docs.
+// Parse resets the flags of fs to their defaults and sets the values specified by args or env
+// by flag.Value.Set, the lazy parsing by Fetch never writes to fs
+//
+//	ImportFlagSet(flag.CommandLine)
+func (x *Argsx) ImportFlagSet(fs *flag.FlagSet) {
+	var flags []Flag
+	fs.VisitAll(func(f *flag.Flag) {
+		flags = append(flags, Flag{
+			Name:    f.Name,
+			Usage:   f.Usage,
+			Type:    flagType(f.Value),
+			Default: f.DefValue,
+		})
+	})
+	x.Register(flags...)
+
+	x.mux.Lock()
+	defer x.mux.Unlock()
+	x.flagSets = append(x.flagSets, fs)
+	x.reset()
+}
+
+// flagType returns the Flag.Type of flag.Value
+func flagType(value flag.Value) string {
+	if b, ok := value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+		return "bool"
+	}
+	getter, ok := value.(flag.Getter)
+	if !ok {
+		return "string"
+	}
+	switch getter.Get().(type) {
+	case int:
+		return "int"
+	case int64:
+		return "int64"
+	case uint:
+		return "uint"
+	case uint64:
+		return "uint64"
+	case float64:
+		return "float64"
+	case time.Duration:
+		return "duration"
+	default:
+		return "string"
+	}
+}
+
+// applyFlagSets resets the flags of imported flag sets to DefValue and
+// sets the values not filled by Default, it's called by Parse only
+func (s *Snapshot) applyFlagSets(flagSets []*flag.FlagSet) error {
+	var errs []error
+	for _, fs := range flagSets {
+		fs.VisitAll(func(f *flag.Flag) {
+			if err := f.Value.Set(f.DefValue); err != nil {
+				errs = append(errs, fmt.Errorf("reset flag `%s` to default `%s`: %w", f.Name, f.DefValue, err))
+			}
+
+			ck := s.resolver.canonical(f.Name)
+			value, ok := s.values[ck]
+			if !ok || s.defaulted[ck] {
+				return
+			}
+
+			payload := value.payload
+			if len(payload) == 0 && flagType(f.Value) == "bool" {
+				payload = "true"
+			}
+			if err := fs.Set(f.Name, payload); err != nil {
+				errs = append(errs, fmt.Errorf("invalid value `%s` for key: `%s`: %w", payload, value.fullkey, err))
+			}
+		})
+	}
+	return errors.Join(errs...)
+}
+
+// ImportFlagSet registers the flags defined in fs on the default instance
+func ImportFlagSet(fs *flag.FlagSet) {
+	dx.ImportFlagSet(fs)
+}
+
+// FlagValue adapts Value to flag.Getter, so a Value can be defined in flag.FlagSet
+// and read by the getters of Value after flag parsing
+//
+//	port := &FlagValue{Value: NewV("8080")}
+//	flag.Var(port, "port", "listen port")
+//	flag.Parse()
+//	port.MustInt() // 8080 or the value of -port
+type FlagValue struct {
+	Value
+}
+
+// String returns the payload implements flag.Value
+func (v *FlagValue) String() string {
+	if v == nil {
+		return ""
+	}
+	return v.payload
+}
+
+// Set replaces the payload implements flag.Value
+func (v *FlagValue) Set(payload string) error {
+	v.payload = payload
+	return nil
+}
+
+// Get returns the payload implements flag.Getter
+func (v *FlagValue) Get() any {
+	return v.payload
+}
@@ -0,0 +1,69 @@
+package argsx
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportFlagSet(t *testing.T) {
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	port := fs.Int("port", 80, "listen port")
+	debug := fs.Bool("debug", false, "debug mode")
+	timeout := fs.Duration("timeout", time.Second, "request timeout")
+	name := fs.String("name", "app", "service name")
+
+	x := NewWithArgs([]string{"app", "--port", "8080", "--debug", "--timeout=3s"})
+	x.ImportFlagSet(fs)
+	require.NoError(t, x.Parse())
+	require.Equal(t, 8080, *port)
+	require.True(t, *debug)
+	require.Equal(t, 3*time.Second, *timeout)
+	require.Equal(t, "app", *name)
+	require.Equal(t, "app", x.Fetch("name").MustString())
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, x.WriteHelp(buf))
+	require.Contains(t, buf.String(), "  --port int           listen port (default \"80\")\n")
+	require.Contains(t, buf.String(), "  --timeout duration   request timeout (default \"1s\")\n")
+
+	// old values are reset after args changed
+	x.SetArgs([]string{"app", "--name", "svc"})
+	require.NoError(t, x.Parse())
+	require.Equal(t, 80, *port)
+	require.False(t, *debug)
+	require.Equal(t, time.Second, *timeout)
+	require.Equal(t, "svc", *name)
+
+	// bool flags are switches take no positional value
+	x.SetArgs([]string{"app", "--debug", "input.txt"})
+	require.NoError(t, x.Parse())
+	require.True(t, *debug)
+
+	// lazy parsing never writes to flag sets
+	x.SetArgs([]string{"app", "--port", "9090"})
+	require.Equal(t, 9090, x.Fetch("port").MustInt())
+	require.Equal(t, 80, *port)
+	require.True(t, *debug)
+
+	x.SetArgs([]string{"app", "--port", "abc"})
+	require.Error(t, x.Parse())
+}
+
+func TestFlagValue(t *testing.T) {
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	port := &FlagValue{Value: NewV("80")}
+	hosts := &FlagValue{}
+	fs.Var(port, "port", "listen port")
+	fs.Var(hosts, "hosts", "hosts")
+
+	var getter flag.Getter = port
+	require.Equal(t, "80", getter.String())
+	require.NoError(t, fs.Parse([]string{"-port", "8080", "-hosts", "a,b"}))
+	require.Equal(t, 8080, port.MustInt())
+	require.Equal(t, "8080", port.Get())
+	require.Equal(t, []string{"a", "b"}, hosts.MustStringSlice())
+}
@@ -0,0 +1,135 @@
+package argsx
+
+import (
+	"sort"
+	"strings"
+)
+
+// keySeparator separates the scopes of dotted keys
+const keySeparator = "."
+
+// View is a scoped view of Argsx reads the keys under a prefix
+type View struct {
+	x      *Argsx
+	prefix string
+}
+
+// Sub returns a view of keys under prefix, Fetch of the view reads prefix.key
+//
+//	os.Args = []string{"--db.host", "localhost", "--db.port", "3306"}
+//	Sub("db").Fetch("host").String() // "localhost", nil
+func (x *Argsx) Sub(prefix string) *View {
+	return &View{x: x, prefix: strings.Trim(prefix, keySeparator)}
+}
+
+// Sub returns a view of keys under prefix of this view
+//
+//	Sub("db").Sub("primary").Fetch("host") // reads db.primary.host
+func (v *View) Sub(prefix string) *View {
+	return v.x.Sub(v.key(prefix))
+}
+
+// Fetch get the value of prefix.key
+func (v *View) Fetch(key string) Value {
+	return v.x.Fetch(v.key(key))
+}
+
+// Tree returns nested map of keys under the prefix, see Argsx.Tree
+func (v *View) Tree() map[string]any {
+	s := v.x.Snapshot()
+	tree := s.Tree()
+	if len(v.prefix) == 0 {
+		return tree
+	}
+	for _, scope := range strings.Split(v.prefix, keySeparator) {
+		sub, ok := tree[s.resolver.normalize(scope)].(map[string]any)
+		if !ok {
+			return map[string]any{}
+		}
+		tree = sub
+	}
+	return tree
+}
+
+// key returns the full key of key in the view
+func (v *View) key(key string) string {
+	if len(v.prefix) == 0 {
+		return key
+	}
+	return v.prefix + keySeparator + key
+}
+
+// Tree returns nested map built from dotted keys, the leaves are payloads and secrets are redacted.
+// When a key is both a value and a scope like a and a.b, the value is stored with empty key
+//
+//	os.Args = []string{"--db.host", "localhost", "--db.port", "3306", "--debug"}
+//	Tree() // map[string]any{"db": map[string]any{"host": "localhost", "port": "3306"}, "debug": ""}
+func (x *Argsx) Tree() map[string]any {
+	return x.Snapshot().Tree()
+}
+
+// scopes returns the normalized scopes of key, the scopes are split from the key specified
+// in args or its alias target before normalizing, so normalizers folding '.' keep the scopes
+func (s *Snapshot) scopes(key string) []string {
+	value := s.values[key]
+	original := strings.TrimLeft(value.fullkey, "-")
+	if target, ok := s.resolver.target(original); ok {
+		original = target
+	}
+	if s.resolver.canonical(original) != key {
+		original = key
+	}
+
+	scopes := strings.Split(original, keySeparator)
+	for idx, scope := range scopes {
+		scopes[idx] = s.resolver.normalize(scope)
+	}
+	return scopes
+}
+
+// Tree returns nested map built from dotted keys of the snapshot, see Argsx.Tree
+func (s *Snapshot) Tree() map[string]any {
+	keys := make([]string, 0, len(s.values))
+	for key := range s.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	tree := make(map[string]any)
+	for _, key := range keys {
+		scopes := s.scopes(key)
+		node := tree
+		for _, scope := range scopes[:len(scopes)-1] {
+			switch sub := node[scope].(type) {
+			case map[string]any:
+				node = sub
+			case string:
+				child := map[string]any{"": sub}
+				node[scope] = child
+				node = child
+			default:
+				child := make(map[string]any)
+				node[scope] = child
+				node = child
+			}
+		}
+
+		leaf := scopes[len(scopes)-1]
+		if sub, ok := node[leaf].(map[string]any); ok {
+			sub[""] = s.values[key].Raw()
+		} else {
+			node[leaf] = s.values[key].Raw()
+		}
+	}
+	return tree
+}
+
+// Sub returns a view of keys under prefix of the default instance
+func Sub(prefix string) *View {
+	return dx.Sub(prefix)
+}
+
+// Tree returns nested map built from dotted keys of the default instance
+func Tree() map[string]any {
+	return dx.Tree()
+}
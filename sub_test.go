@@ -0,0 +1,50 @@
+package argsx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSub(t *testing.T) {
+	x := NewWithArgs([]string{"app",
+		"--db.host", "localhost",
+		"--db.port", "3306",
+		"--db.primary.host", "10.0.0.1",
+		"--string.slice", "A,B",
+		"--string.slice.delimiter", "/",
+		"--debug",
+	})
+
+	db := x.Sub("db")
+	require.Equal(t, "localhost", db.Fetch("host").MustString())
+	require.Equal(t, 3306, db.Fetch("port").MustInt())
+	require.Equal(t, "10.0.0.1", db.Sub("primary").Fetch("host").MustString())
+	require.Equal(t, Value{}, db.Fetch("debug"))
+
+	require.Equal(t, map[string]any{
+		"db": map[string]any{
+			"host":    "localhost",
+			"port":    "3306",
+			"primary": map[string]any{"host": "10.0.0.1"},
+		},
+		"string": map[string]any{
+			"slice": map[string]any{"": "A,B", "delimiter": "/"},
+		},
+		"debug": "",
+	}, x.Tree())
+	require.Equal(t, map[string]any{"host": "10.0.0.1"}, db.Sub("primary").Tree())
+	require.Equal(t, map[string]any{}, x.Sub("missing").Tree())
+}
+
+func TestTreeNormalized(t *testing.T) {
+	x := NewWithArgs([]string{"app", "--DB.Host", "localhost", "--db.max_conns", "10", "-p", "3306"})
+	x.SetNormalizer(KebabCase)
+	x.Alias("db.port", "p")
+
+	require.Equal(t, "localhost", x.Sub("db").Fetch("host").MustString())
+	require.Equal(t, map[string]any{
+		"db": map[string]any{"host": "localhost", "max-conns": "10", "port": "3306"},
+	}, x.Tree())
+	require.Equal(t, map[string]any{"host": "localhost", "max-conns": "10", "port": "3306"}, x.Sub("DB").Tree())
+}
@@ -0,0 +1,120 @@
+package argsx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// autoLayouts are tried in order by parseAnyTime after the custom layouts
+var autoLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	time.DateTime,
+	"2006-01-02 15:04",
+	time.DateOnly,
+	"2006/01/02 15:04:05",
+	"2006/01/02",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.RubyDate,
+	time.UnixDate,
+	time.ANSIC,
+}
+
+// getLocation returns the location specified by WithLocation or WithLocationName, default is UTC
+func (opts options[T]) getLocation() (*time.Location, error) {
+	if opts.location != nil {
+		return opts.location, nil
+	}
+	if len(opts.locationName) > 0 {
+		return time.LoadLocation(opts.locationName)
+	}
+	return time.UTC, nil
+}
+
+// timeParser returns a parser parse payload by layout in the location of option
+func timeParser(layout string, option *options[time.Time]) parser[time.Time] {
+	return func(payload string) (time.Time, error) {
+		loc, err := option.getLocation()
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.ParseInLocation(layout, payload, loc)
+	}
+}
+
+// anyTimeParser returns a parser try custom layouts, the built-in layouts and unix epochs in order
+func anyTimeParser(option *options[time.Time]) parser[time.Time] {
+	return func(payload string) (time.Time, error) {
+		loc, err := option.getLocation()
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if epoch, err := strconv.ParseInt(payload, 10, 64); err == nil {
+			return parseEpoch(epoch).In(loc), nil
+		}
+
+		payload = strings.TrimSpace(payload)
+		for _, layouts := range [][]string{option.layouts, autoLayouts} {
+			for _, layout := range layouts {
+				if t, err := time.ParseInLocation(layout, payload, loc); err == nil {
+					return t, nil
+				}
+			}
+		}
+		return time.Time{}, fmt.Errorf("invalid time value `%s`: unknown format", payload)
+	}
+}
+
+// parseEpoch convert unix epoch to time.Time, the unit is detected by magnitude:
+// seconds, milliseconds, microseconds or nanoseconds
+func parseEpoch(epoch int64) time.Time {
+	abs := epoch
+	if abs < 0 {
+		abs = -abs
+	}
+	switch {
+	case abs < 1e11:
+		return time.Unix(epoch, 0)
+	case abs < 1e14:
+		return time.UnixMilli(epoch)
+	case abs < 1e17:
+		return time.UnixMicro(epoch)
+	default:
+		return time.Unix(0, epoch)
+	}
+}
+
+// WithLocation specify the location of time getters parse in, default is UTC
+//
+//	WithLocation(time.Local)
+func WithLocation(loc *time.Location) Option[time.Time] {
+	return func(opts *options[time.Time]) {
+		opts.location = loc
+	}
+}
+
+// WithLocationName specify the location by IANA name, it's loaded when parsing
+//
+//	WithLocationName("Local")
+//	WithLocationName("Asia/Shanghai")
+func WithLocationName(name string) Option[time.Time] {
+	return func(opts *options[time.Time]) {
+		opts.locationName = name
+	}
+}
+
+// WithLayouts specify layouts AutoTime tries before the built-in layouts
+//
+//	WithLayouts("02/01/2006", time.Kitchen)
+func WithLayouts(layouts ...string) Option[time.Time] {
+	return func(opts *options[time.Time]) {
+		opts.layouts = append(opts.layouts, layouts...)
+	}
+}
@@ -0,0 +1,105 @@
+package argsx
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ordered is a constraint that permits any type supports the < <= >= > operators
+type ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+// validate runs all validators against t, returns the first error
+func (opts options[T]) validate(t T) error {
+	for _, validator := range opts.validators {
+		if err := validator(t); err != nil {
+			if opts.secret {
+				return redactError(err, fmt.Sprint(t))
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate returns a function checks the result of getter by validators of opts,
+// slice getters accept the same options directly and check each element
+//
+//	Validate(WithRange(1, 65535))(Fetch("port").Int()) // port, nil or 0, error
+//	Validate(WithPattern(`^\w+$`))(Fetch("name").String()) // name, nil or "", error
+func Validate[T any](opts ...Option[T]) func(T, error) (T, error) {
+	option := getOpts(opts)
+	return func(t T, err error) (zero T, _ error) {
+		if err != nil {
+			return zero, err
+		}
+		if err = option.validate(t); err != nil {
+			return zero, err
+		}
+		return t, nil
+	}
+}
+
+// WithValidator append a custom validator, the value is rejected when it returns non-nil error
+//
+//	WithValidator(func(s string) error { return nil })
+func WithValidator[T any](validator func(T) error) Option[T] {
+	return func(opts *options[T]) {
+		opts.validators = append(opts.validators, validator)
+	}
+}
+
+// WithMin rejects the value less than min
+//
+//	WithMin(1)
+//	WithMin(time.Second)
+func WithMin[T ordered](min T) Option[T] {
+	return WithValidator(func(t T) error {
+		if t < min {
+			return fmt.Errorf("invalid value `%v`: must be greater than or equal to %v", t, min)
+		}
+		return nil
+	})
+}
+
+// WithMax rejects the value greater than max
+//
+//	WithMax(100)
+//	WithMax(float32(1.5))
+func WithMax[T ordered](max T) Option[T] {
+	return WithValidator(func(t T) error {
+		if t > max {
+			return fmt.Errorf("invalid value `%v`: must be less than or equal to %v", t, max)
+		}
+		return nil
+	})
+}
+
+// WithRange rejects the value not in [min, max]
+//
+//	WithRange(1, 65535)
+func WithRange[T ordered](min, max T) Option[T] {
+	return WithValidator(func(t T) error {
+		if t < min || t > max {
+			return fmt.Errorf("invalid value `%v`: must be in range [%v, %v]", t, min, max)
+		}
+		return nil
+	})
+}
+
+// WithPattern rejects the value not matches the regular expression,
+// it panics if the pattern can not be compiled like regexp.MustCompile
+//
+//	WithPattern(`^[a-z]+$`)
+func WithPattern(pattern string) Option[string] {
+	re := regexp.MustCompile(pattern)
+	return WithValidator(func(s string) error {
+		if !re.MatchString(s) {
+			return fmt.Errorf("invalid value `%s`: must match pattern %s", s, pattern)
+		}
+		return nil
+	})
+}
@@ -11,6 +11,7 @@ import (
 type Value struct {
 	fullkey string
 	payload string
+	secret  bool
 }
 
 // parser is a generic type convert string to T
@@ -21,6 +22,19 @@ func NewV(payload string) Value {
 	return Value{payload: payload}
 }
 
+// Key returns the key with dashes as specified in args like `--port`
+func (v Value) Key() string {
+	return v.fullkey
+}
+
+// Raw returns the original payload without conversion, secret payload is redacted, use Reveal instead
+func (v Value) Raw() string {
+	if v.secret {
+		return redacted
+	}
+	return v.payload
+}
+
 // get returns parse result of T type, if payload is not specified return default value or zero value
 func get[T any](v Value, dv []T, parse parser[T]) (t T, err error) {
 	if len(v.payload) == 0 {
@@ -32,7 +46,11 @@ func get[T any](v Value, dv []T, parse parser[T]) (t T, err error) {
 		}
 		return t, fmt.Errorf("args not specified value for key: `%s`", v.fullkey)
 	}
-	return parse(v.payload)
+	t, err = parse(v.payload)
+	if err != nil && v.secret {
+		return t, redactError(err, v.payload)
+	}
+	return t, err
 }
 
 // must check the err if nil then return val otherwise return zero value of T type
@@ -43,10 +61,14 @@ func must[T any](val T, err error) (t T) {
 	return val
 }
 
-// toSlice convert payload string to slice of T type
-func toSlice[T any](payload, delimiter string, parse parser[T], dv ...T) ([]T, error) {
+// toSlice convert payload string to slice of T type, each element is checked by validators of option
+func toSlice[T any](payload string, option *options[T], parse parser[T], dv ...T) ([]T, error) {
+	arr, err := option.split(payload)
+	if err != nil {
+		return nil, err
+	}
+
 	var slice []T
-	arr := strings.Split(payload, delimiter)
 	for _, str := range arr {
 		if len(str) == 0 {
 			if len(dv) > 0 {
@@ -57,6 +79,12 @@ func toSlice[T any](payload, delimiter string, parse parser[T], dv ...T) ([]T, e
 
 		b, err := parse(str)
 		if err != nil {
+			if option.secret {
+				return nil, redactError(err, str)
+			}
+			return nil, err
+		}
+		if err = option.validate(b); err != nil {
 			return nil, err
 		}
 		slice = append(slice, b)
@@ -64,13 +92,16 @@ func toSlice[T any](payload, delimiter string, parse parser[T], dv ...T) ([]T, e
 	return slice, nil
 }
 
-// String returns string value
+// String returns string value, the payload of secret value is redacted, see Reveal
 //
 //	NewValue("string value").String() // "string value", nil
 //	NewValue("").String() // "", error
 //	NewValue("").String("default value") // "default value", nil
 func (v Value) String(dv ...string) (string, error) {
 	return get(v, dv, func(payload string) (string, error) {
+		if v.secret {
+			return redacted, nil
+		}
 		return payload, nil
 	})
 }
@@ -84,19 +115,38 @@ func (v Value) MustString(dv ...string) string {
 	return must(v.String(dv...))
 }
 
-// StringSlice returns []string and error
+// StringSlice returns []string and error, the elements of secret value are redacted
 //
 //	NewValue("A,B,C").StringSlice() // []string{"A", "B", "C"}, nil
 //	NewValue("").StringSlice() // nil, error
 //	NewValue("").StringSlice(WithDefault[string]("D", "E", "F")) // []string{"D", "E", "F"}, error
 //	NewValue("G/H/I").StringSlice(WithDelimiter[string]("/")) // []string{"G", "H", "I"}, error
 func (v Value) StringSlice(opts ...Option[string]) ([]string, error) {
-	option := getOpts(opts)
+	option := getOpts(opts).secretOf(v)
 	return get(v, option.getDefault(), func(payload string) ([]string, error) {
-		return strings.Split(payload, option.delimiter), nil
+		slice, err := option.split(payload)
+		if err != nil {
+			return nil, err
+		}
+		for _, str := range slice {
+			if err := option.validate(str); err != nil {
+				return nil, err
+			}
+		}
+		return v.redactSlice(slice), nil
 	})
 }
 
+// redactSlice replaces the elements of secret value with redacted
+func (v Value) redactSlice(slice []string) []string {
+	if v.secret {
+		for idx := range slice {
+			slice[idx] = redacted
+		}
+	}
+	return slice
+}
+
 // MustStringSlice returns []string ignore error
 //
 //	NewValue("A,B,C").MustStringSlice() // []string{"A", "B", "C"}
@@ -107,6 +157,63 @@ func (v Value) MustStringSlice(opts ...Option[string]) []string {
 	return must(v.StringSlice(opts...))
 }
 
+// enum returns a parser accepts only one of choices, when fold is true
+// the payload is compared case-insensitively and the declared choice is returned,
+// the choice and the payload in error of secret value are redacted
+func (v Value) enum(choices []string, fold bool) parser[string] {
+	return func(payload string) (string, error) {
+		for _, choice := range choices {
+			if choice == payload || (fold && strings.EqualFold(choice, payload)) {
+				if v.secret {
+					return redacted, nil
+				}
+				return choice, nil
+			}
+		}
+		if v.secret {
+			payload = redacted
+		}
+		return "", fmt.Errorf("invalid value `%s` for key: `%s`, must be one of [%s]",
+			payload, v.fullkey, strings.Join(choices, ", "))
+	}
+}
+
+// Enum returns string value which must be one of choices, compare is case-sensitive
+//
+//	NewValue("json").Enum([]string{"json", "yaml"}) // "json", nil
+//	NewValue("JSON").Enum([]string{"json", "yaml"}) // "", error
+//	NewValue("").Enum([]string{"json", "yaml"}, "yaml") // "yaml", nil
+//	NewValue("xml").Enum([]string{"json", "yaml"}) // "", error
+func (v Value) Enum(choices []string, dv ...string) (string, error) {
+	return get(v, dv, v.enum(choices, false))
+}
+
+// MustEnum returns string value which must be one of choices ignore error
+//
+//	NewValue("json").MustEnum([]string{"json", "yaml"}) // "json"
+//	NewValue("").MustEnum([]string{"json", "yaml"}, "yaml") // "yaml"
+//	NewValue("xml").MustEnum([]string{"json", "yaml"}) // ""
+func (v Value) MustEnum(choices []string, dv ...string) string {
+	return must(v.Enum(choices, dv...))
+}
+
+// EnumFold returns the matched choice, compare is case-insensitive
+//
+//	NewValue("JSON").EnumFold([]string{"json", "yaml"}) // "json", nil
+//	NewValue("").EnumFold([]string{"json", "yaml"}, "yaml") // "yaml", nil
+//	NewValue("xml").EnumFold([]string{"json", "yaml"}) // "", error
+func (v Value) EnumFold(choices []string, dv ...string) (string, error) {
+	return get(v, dv, v.enum(choices, true))
+}
+
+// MustEnumFold returns the matched choice ignore error, compare is case-insensitive
+//
+//	NewValue("Yaml").MustEnumFold([]string{"json", "yaml"}) // "yaml"
+//	NewValue("xml").MustEnumFold([]string{"json", "yaml"}) // ""
+func (v Value) MustEnumFold(choices []string, dv ...string) string {
+	return must(v.EnumFold(choices, dv...))
+}
+
 // Bool returns bool value of payload
 //
 //	NewValue("true").Bool() // true, nil
@@ -136,9 +243,9 @@ func (v Value) MustBool(dv ...bool) bool {
 //	NewValue("true,F").BoolSlice(WithDelimiter[bool](";")) // []bool{true, false}, nil
 //	NewValue("").BoolSlice(WithDefault[bool](true, false)) // []bool{true, false}, nil
 func (v Value) BoolSlice(opts ...Option[bool]) ([]bool, error) {
-	option := getOpts(opts)
+	option := getOpts(opts).secretOf(v)
 	return get(v, option.getDefault(), func(payload string) ([]bool, error) {
-		return toSlice(payload, option.delimiter, strconv.ParseBool, true)
+		return toSlice(payload, option, strconv.ParseBool, true)
 	})
 }
 
@@ -172,16 +279,37 @@ func (v Value) MustDuration(dv ...time.Duration) time.Duration {
 	return must(v.Duration(dv...))
 }
 
+// ExtendedDuration returns time.Duration value of payload parsed by ParseDuration,
+// supports d and w units and ISO 8601 durations
+//
+//	NewValue("7d").ExtendedDuration() // time.Hour*168, nil
+//	NewValue("P1DT12H").ExtendedDuration() // time.Hour*36, nil
+//	NewValue("").ExtendedDuration(time.Hour) // time.Hour, nil
+//	NewValue("abc").ExtendedDuration() // time.Duration(0), error
+func (v Value) ExtendedDuration(dv ...time.Duration) (time.Duration, error) {
+	return get(v, dv, ParseDuration)
+}
+
+// MustExtendedDuration returns time.Duration value of payload parsed by ParseDuration ignore error
+//
+//	NewValue("2w").MustExtendedDuration() // time.Hour*336
+//	NewValue("").MustExtendedDuration() // time.Duration(0)
+//	NewValue("abc").MustExtendedDuration() // time.Duration(0)
+func (v Value) MustExtendedDuration(dv ...time.Duration) time.Duration {
+	return must(v.ExtendedDuration(dv...))
+}
+
 // DurationSlice returns []time.Duration
 //
 //	NewValue("1m,2s").DurationSlice() // []time.Duration{time.Minute, time.Second*2}, nil
 //	NewValue("").DurationSlice() // nil, error
 //	NewValue("1m;2s").DurationSlice(WithDelimiter[time.Duration](";")) // []time.Duration{time.Minute, time.Second*2}, nil
 //	NewValue("").DurationSlice(WithDefault[time.Duration](time.Minute, time.Second)) // []time.Duration{time.Minute, time.Second}, nil
+//	NewValue("1d,P1W").DurationSlice(WithExtendedDuration()) // []time.Duration{time.Hour*24, time.Hour*168}, nil
 func (v Value) DurationSlice(opts ...Option[time.Duration]) ([]time.Duration, error) {
-	option := getOpts(opts)
+	option := getOpts(opts).secretOf(v)
 	return get(v, option.getDefault(), func(payload string) ([]time.Duration, error) {
-		return toSlice(payload, option.delimiter, time.ParseDuration)
+		return toSlice(payload, option, option.getParser(time.ParseDuration))
 	})
 }
 
@@ -217,29 +345,99 @@ func (v Value) MustTime(layout string, dv ...time.Time) time.Time {
 	return must(v.Time(layout, dv...))
 }
 
+// TimeIn returns time.Time value of payload parsed in the location
+//
+//	NewValue("2023-07-26").TimeIn(time.DateOnly, time.Local) // 2023-07-26 00:00:00 local, nil
+//	NewValue("").TimeIn(time.DateOnly, time.Local) // time.Time{}, error
+//	NewValue("").TimeIn(time.DateOnly, time.Local, time.Now()) // current local time, nil
+func (v Value) TimeIn(layout string, loc *time.Location, dv ...time.Time) (time.Time, error) {
+	return get(v, dv, func(payload string) (time.Time, error) {
+		return time.ParseInLocation(layout, payload, loc)
+	})
+}
+
+// MustTimeIn returns time.Time value of payload parsed in the location ignore error
+//
+//	NewValue("2023-07-26").MustTimeIn(time.DateOnly, time.Local) // 2023-07-26 00:00:00 local
+//	NewValue("").MustTimeIn(time.DateOnly, time.Local) // time.Time{}
+//	NewValue("abc").MustTimeIn(time.DateOnly, time.Local) // time.Time{}
+func (v Value) MustTimeIn(layout string, loc *time.Location, dv ...time.Time) time.Time {
+	return must(v.TimeIn(layout, loc, dv...))
+}
+
 // TimeSlice returns []time.Time
 //
-//	NewValue("3:04PM,4:03PM").TimeSlice() // []time.Time{3:04PM, 4:03PM}, nil
-//	NewValue("").TimeSlice() // nil, error
-//	NewValue("3:04PM;4:03PM").TimeSlice(WithDelimiter[time.Time](";")) // []time.Time{3:04PM, 4:03PM}, nil
-//	NewValue("").TimeSlice(WithDefault[time.Time](time.Now())) // []time.Time{current local time}, nil
+//	NewValue("3:04PM,4:03PM").TimeSlice(time.Kitchen) // []time.Time{3:04PM, 4:03PM}, nil
+//	NewValue("").TimeSlice(time.Kitchen) // nil, error
+//	NewValue("3:04PM;4:03PM").TimeSlice(time.Kitchen, WithDelimiter[time.Time](";")) // []time.Time{3:04PM, 4:03PM}, nil
+//	NewValue("").TimeSlice(time.Kitchen, WithDefault[time.Time](time.Now())) // []time.Time{current local time}, nil
+//	NewValue("3:04PM").TimeSlice(time.Kitchen, WithLocation(time.Local)) // []time.Time{3:04PM local}, nil
 func (v Value) TimeSlice(layout string, opts ...Option[time.Time]) ([]time.Time, error) {
-	option := getOpts(opts)
+	option := getOpts(opts).secretOf(v)
 	return get(v, option.getDefault(), func(payload string) ([]time.Time, error) {
-		return toSlice(payload, option.delimiter, func(payload string) (time.Time, error) {
-			return time.Parse(layout, payload)
-		})
+		return toSlice(payload, option, timeParser(layout, option))
 	})
 }
 
 // MustTimeSlice return []time.Time if error not nil will be ignored
 //
-//	NewValue("3:04PM,4:03PM").MustTimeSlice() // []time.Time{3:04PM, 4:03PM}
-//	NewValue("").MustTimeSlice() // nil
-//	NewValue("3:04PM;4:03PM").MustTimeSlice(WithDelimiter[time.Time](";")) // []time.Time{3:04PM, 4:03PM}
-//	NewValue("").MustTimeSlice(WithDefault[time.Time](time.Now())) // []time.Time{current local time}
-func (v Value) MustTimeSlice(layout string, opts ...Option[time.Duration]) []time.Duration {
-	return must(v.DurationSlice(opts...))
+//	NewValue("3:04PM,4:03PM").MustTimeSlice(time.Kitchen) // []time.Time{3:04PM, 4:03PM}
+//	NewValue("").MustTimeSlice(time.Kitchen) // nil
+//	NewValue("3:04PM;4:03PM").MustTimeSlice(time.Kitchen, WithDelimiter[time.Time](";")) // []time.Time{3:04PM, 4:03PM}
+//	NewValue("").MustTimeSlice(time.Kitchen, WithDefault[time.Time](time.Now())) // []time.Time{current local time}
+func (v Value) MustTimeSlice(layout string, opts ...Option[time.Time]) []time.Time {
+	return must(v.TimeSlice(layout, opts...))
+}
+
+// AutoTime returns time.Time value of payload, the layout is detected from
+// RFC3339, datetimes, dates and unix epochs in seconds, milliseconds, microseconds or nanoseconds
+//
+//	NewValue("2023-07-26T15:04:05+08:00").AutoTime() // 2023-07-26 15:04:05 +0800, nil
+//	NewValue("2023-07-26 15:04:05").AutoTime(WithLocationName("Asia/Shanghai")) // 2023-07-26 15:04:05 CST, nil
+//	NewValue("1690355045").AutoTime() // 2023-07-26 07:04:05 UTC, nil
+//	NewValue("1690355045000").AutoTime(WithLocation(time.Local)) // 2023-07-26 07:04:05 UTC in local, nil
+//	NewValue("26/07/2023").AutoTime(WithLayouts("02/01/2006")) // 2023-07-26 00:00:00 UTC, nil
+//	NewValue("").AutoTime(WithDefault(time.Now())) // current local time, nil
+//	NewValue("abc").AutoTime() // time.Time{}, error
+func (v Value) AutoTime(opts ...Option[time.Time]) (time.Time, error) {
+	option := getOpts(opts).secretOf(v)
+	t, err := get(v, option.defaultV, anyTimeParser(option))
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err = option.validate(t); err != nil {
+		return time.Time{}, err
+	}
+	return t, nil
+}
+
+// MustAutoTime returns time.Time value of payload ignore error
+//
+//	NewValue("2023-07-26").MustAutoTime() // 2023-07-26 00:00:00 UTC
+//	NewValue("").MustAutoTime() // time.Time{}
+//	NewValue("abc").MustAutoTime() // time.Time{}
+func (v Value) MustAutoTime(opts ...Option[time.Time]) time.Time {
+	return must(v.AutoTime(opts...))
+}
+
+// AutoTimeSlice returns []time.Time, each element is detected like AutoTime
+//
+//	NewValue("2023-07-26,1690355045").AutoTimeSlice() // []time.Time{2023-07-26 00:00:00 UTC, 2023-07-26 07:04:05 UTC}, nil
+//	NewValue("").AutoTimeSlice() // nil, error
+//	NewValue("2023-07-26;2023-07-27").AutoTimeSlice(WithDelimiter[time.Time](";")) // []time.Time{2023-07-26, 2023-07-27}, nil
+func (v Value) AutoTimeSlice(opts ...Option[time.Time]) ([]time.Time, error) {
+	option := getOpts(opts).secretOf(v)
+	return get(v, option.getDefault(), func(payload string) ([]time.Time, error) {
+		return toSlice(payload, option, anyTimeParser(option))
+	})
+}
+
+// MustAutoTimeSlice returns []time.Time if error not nil will be ignored
+//
+//	NewValue("2023-07-26,1690355045").MustAutoTimeSlice() // []time.Time{2023-07-26 00:00:00 UTC, 2023-07-26 07:04:05 UTC}
+//	NewValue("").MustAutoTimeSlice() // nil
+func (v Value) MustAutoTimeSlice(opts ...Option[time.Time]) []time.Time {
+	return must(v.AutoTimeSlice(opts...))
 }
 
 // Int returns int value
@@ -270,9 +468,9 @@ func (v Value) MustInt(dv ...int) int {
 //	NewValue("").IntSlice(WithDefault[int](4, 5, 6)) // []int{4, 5, 6}, nil
 //	NewValue("7;8;9").IntSlice(WithDelimiter[int](";")) // []int{7, 8, 9}, nil
 func (v Value) IntSlice(opts ...Option[int]) ([]int, error) {
-	option := getOpts(opts)
+	option := getOpts(opts).secretOf(v)
 	return get(v, option.getDefault(), func(payload string) ([]int, error) {
-		return toSlice(payload, option.delimiter, strconv.Atoi)
+		return toSlice(payload, option, strconv.Atoi)
 	})
 }
 
@@ -323,9 +521,9 @@ func (v Value) MustInt8(dv ...int8) int8 {
 //	NewValue("").Int8Slice(WithDefault[int8](4, 5, 6)) // []int8{4, 5, 6}, nil
 //	NewValue("1;2;3").Int8Slice(WithDelimiter[int8](";")) // []int8{1, 2, 3}, nil
 func (v Value) Int8Slice(opts ...Option[int8]) ([]int8, error) {
-	option := getOpts(opts)
+	option := getOpts(opts).secretOf(v)
 	return get(v, option.getDefault(), func(payload string) ([]int8, error) {
-		return toSlice(payload, option.delimiter, parseInt8)
+		return toSlice(payload, option, parseInt8)
 	})
 }
 
@@ -375,9 +573,9 @@ func (v Value) MustInt16(dv ...int16) int16 {
 //	NewValue("").Int16Slice(WithDefault[int16](4, 5, 6)) // []int16{4, 5, 6}, nil
 //	NewValue("1;2;3").Int16Slice(WithDelimiter[int16](";")) // []int16{1, 2, 3}, nil
 func (v Value) Int16Slice(opts ...Option[int16]) ([]int16, error) {
-	option := getOpts(opts)
+	option := getOpts(opts).secretOf(v)
 	return get(v, option.getDefault(), func(payload string) ([]int16, error) {
-		return toSlice(payload, option.delimiter, parseInt16)
+		return toSlice(payload, option, parseInt16)
 	})
 }
 
@@ -427,9 +625,9 @@ func (v Value) MustInt32(dv ...int32) int32 {
 //	NewValue("").Int32Slice(WithDefault[int32](4, 5, 6)) // []int32{4, 5, 6}, nil
 //	NewValue("1;2;3").Int32Slice(WithDelimiter[int32](";")) // []int32{1, 2, 3}, nil
 func (v Value) Int32Slice(opts ...Option[int32]) ([]int32, error) {
-	option := getOpts(opts)
+	option := getOpts(opts).secretOf(v)
 	return get(v, option.getDefault(), func(payload string) ([]int32, error) {
-		return toSlice(payload, option.delimiter, parseInt32)
+		return toSlice(payload, option, parseInt32)
 	})
 }
 
@@ -475,9 +673,9 @@ func (v Value) MustInt64(dv ...int64) int64 {
 //	NewValue("").Int64Slice(WithDefault[int64](4, 5, 6)) // []int64{4, 5, 6}, nil
 //	NewValue("1;2;3").Int64Slice(WithDelimiter[int64](";")) // []int64{1, 2, 3}, nil
 func (v Value) Int64Slice(opts ...Option[int64]) ([]int64, error) {
-	option := getOpts(opts)
+	option := getOpts(opts).secretOf(v)
 	return get(v, option.getDefault(), func(payload string) ([]int64, error) {
-		return toSlice(payload, option.delimiter, parseInt64)
+		return toSlice(payload, option, parseInt64)
 	})
 }
 
@@ -1,50 +1,51 @@
 package argsx
 
 import (
-	"os"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
 
 func TestString(t *testing.T) {
-	os.Args = append(os.Args,
+	x := NewWithArgs([]string{"app",
 		"--string.value", "string value",
 		"--string.must",
 		"--string.slice", "A,B,C,D",
 		"--string.slice.delimiter", "E-F-G-H",
 		"--string.slice.empty",
-	)
+	})
 
-	value, err := Fetch("string.value").String()
+	value, err := x.Fetch("string.value").String()
 	require.NoError(t, err)
 	require.Equal(t, "string value", value)
 
-	value = Fetch("string.must").MustString()
+	value = x.Fetch("string.must").MustString()
 	require.Equal(t, "", value)
 
-	slice, err := Fetch("string.slice").StringSlice()
+	slice, err := x.Fetch("string.slice").StringSlice()
 	require.NoError(t, err)
 	require.Equal(t, []string{"A", "B", "C", "D"}, slice)
 
-	slice, err = Fetch("string.slice.delimiter").StringSlice(WithDelimiter[string]("-"))
+	slice, err = x.Fetch("string.slice.delimiter").StringSlice(WithDelimiter[string]("-"))
 	require.NoError(t, err)
 	require.Equal(t, []string{"E", "F", "G", "H"}, slice)
 
-	slice, err = Fetch("string.slice.empty").StringSlice()
+	slice, err = x.Fetch("string.slice.empty").StringSlice()
 	require.NotNil(t, err)
 	require.Equal(t, 0, len(slice))
 
-	slice = Fetch("string.slice.empty").MustStringSlice()
+	slice = x.Fetch("string.slice.empty").MustStringSlice()
 	require.Equal(t, 0, len(slice))
 
-	slice, err = Fetch("string.slice.default").StringSlice(WithDefault("Z", "Y"))
+	slice, err = x.Fetch("string.slice.default").StringSlice(WithDefault("Z", "Y"))
 	require.NoError(t, err)
 	require.Equal(t, []string{"Z", "Y"}, slice)
 }
 
 func TestValue(t *testing.T) {
-	os.Args = append(os.Args,
+	x := NewWithArgs([]string{"app",
 		"--int.value", "123",
 		"--int.empty",
 		"--int.default",
@@ -52,36 +53,272 @@ func TestValue(t *testing.T) {
 		"--int.must.value", "12345",
 		"--int.must.empty",
 		"--int.must.default",
-	)
+	})
 
 	// int of value
-	value, err := Fetch("int.value").Int()
+	value, err := x.Fetch("int.value").Int()
 	require.NoError(t, err)
 	require.Equal(t, 123, value)
 
 	// int no value returns error
-	value, err = Fetch("int.empty").Int()
+	value, err = x.Fetch("int.empty").Int()
 	require.NotNil(t, err)
 	require.Equal(t, 0, value)
 
 	// int of default value
-	value, err = Fetch("int.default").Int(1234)
+	value, err = x.Fetch("int.default").Int(1234)
 	require.NoError(t, err)
 	require.Equal(t, 1234, value)
 
-	value, err = Fetch("int.equals").Int()
+	value, err = x.Fetch("int.equals").Int()
 	require.NoError(t, err)
 	require.Equal(t, 987, value)
 
 	// must int of value
-	value = Fetch("int.must.value").MustInt()
+	value = x.Fetch("int.must.value").MustInt()
 	require.Equal(t, 12345, value)
 
 	// mustInt no value 0
-	value = Fetch("int.must.empty").MustInt()
+	value = x.Fetch("int.must.empty").MustInt()
 	require.Equal(t, 0, value)
 
 	// mustInt of default value
-	value = Fetch("int.must.default").MustInt(123456)
+	value = x.Fetch("int.must.default").MustInt(123456)
 	require.Equal(t, 123456, value)
 }
+
+func TestEnum(t *testing.T) {
+	x := NewWithArgs([]string{"app",
+		"--enum.value", "yaml",
+		"--enum.fold", "JSON",
+		"--enum.invalid", "xml",
+		"--enum.default",
+	})
+
+	choices := []string{"json", "yaml", "table"}
+	value, err := x.Fetch("enum.value").Enum(choices)
+	require.NoError(t, err)
+	require.Equal(t, "yaml", value)
+
+	_, err = x.Fetch("enum.fold").Enum(choices)
+	require.Error(t, err)
+
+	value, err = x.Fetch("enum.fold").EnumFold(choices)
+	require.NoError(t, err)
+	require.Equal(t, "json", value)
+
+	_, err = x.Fetch("enum.invalid").Enum(choices)
+	require.EqualError(t, err, "invalid value `xml` for key: `--enum.invalid`, must be one of [json, yaml, table]")
+
+	value = x.Fetch("enum.default").MustEnum(choices, "table")
+	require.Equal(t, "table", value)
+}
+
+func TestValidate(t *testing.T) {
+	x := NewWithArgs([]string{"app",
+		"--validate.port", "8080",
+		"--validate.port.invalid", "70000",
+		"--validate.ports", "80,443,70000",
+		"--validate.name", "argsx",
+		"--validate.names", "a1,b2,c-3",
+	})
+
+	port, err := Validate(WithRange(1, 65535))(x.Fetch("validate.port").Int())
+	require.NoError(t, err)
+	require.Equal(t, 8080, port)
+
+	port, err = Validate(WithRange(1, 65535))(x.Fetch("validate.port.invalid").Int())
+	require.Error(t, err)
+	require.Equal(t, 0, port)
+
+	_, err = Validate(WithMax(1024))(x.Fetch("validate.port").Int())
+	require.Error(t, err)
+
+	ports, err := x.Fetch("validate.ports").IntSlice(WithMin(1), WithMax(65535))
+	require.Error(t, err)
+	require.Nil(t, ports)
+
+	name, err := Validate(WithPattern(`^[a-z]+$`))(x.Fetch("validate.name").String())
+	require.NoError(t, err)
+	require.Equal(t, "argsx", name)
+
+	_, err = x.Fetch("validate.names").StringSlice(WithPattern(`^[a-z]\d$`))
+	require.EqualError(t, err, "invalid value `c-3`: must match pattern ^[a-z]\\d$")
+	require.Panics(t, func() { WithPattern(`[a-z`) })
+
+	names, err := x.Fetch("validate.names").StringSlice(WithValidator(func(s string) error {
+		return nil
+	}))
+	require.NoError(t, err)
+	require.Equal(t, []string{"a1", "b2", "c-3"}, names)
+}
+
+func TestTime(t *testing.T) {
+	x := NewWithArgs([]string{"app",
+		"--time.rfc3339", "2023-07-26T15:04:05+08:00",
+		"--time.datetime", "2023-07-26 15:04:05",
+		"--time.date", "2023-07-26",
+		"--time.unix", "1690355045",
+		"--time.unix.milli", "1690355045123",
+		"--time.custom", "26/07/2023",
+		"--time.slice", "3:04PM,4:03PM",
+		"--time.invalid", "abc",
+	})
+
+	value, err := x.Fetch("time.rfc3339").AutoTime()
+	require.NoError(t, err)
+	require.Equal(t, int64(1690355045), value.Unix())
+
+	value, err = x.Fetch("time.datetime").AutoTime()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2023, 7, 26, 15, 4, 5, 0, time.UTC), value)
+
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+	value, err = x.Fetch("time.datetime").AutoTime(WithLocationName("Asia/Shanghai"))
+	require.NoError(t, err)
+	require.True(t, time.Date(2023, 7, 26, 15, 4, 5, 0, shanghai).Equal(value))
+
+	_, err = x.Fetch("time.datetime").AutoTime(WithLocationName("Nowhere/Unknown"))
+	require.Error(t, err)
+
+	value, err = x.Fetch("time.date").AutoTime()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2023, 7, 26, 0, 0, 0, 0, time.UTC), value)
+
+	value, err = x.Fetch("time.unix").AutoTime()
+	require.NoError(t, err)
+	require.Equal(t, int64(1690355045), value.Unix())
+
+	value, err = x.Fetch("time.unix.milli").AutoTime()
+	require.NoError(t, err)
+	require.Equal(t, int64(1690355045123), value.UnixMilli())
+
+	value, err = x.Fetch("time.custom").AutoTime(WithLayouts("02/01/2006"))
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2023, 7, 26, 0, 0, 0, 0, time.UTC), value)
+
+	_, err = x.Fetch("time.invalid").AutoTime()
+	require.Error(t, err)
+
+	slice := x.Fetch("time.slice").MustTimeSlice(time.Kitchen, WithLocation(shanghai))
+	require.Equal(t, 2, len(slice))
+	require.Equal(t, shanghai, slice[0].Location())
+	require.Equal(t, 16, slice[1].Hour())
+}
+
+func TestExtendedDuration(t *testing.T) {
+	x := NewWithArgs([]string{"app",
+		"--duration.days", "7d",
+		"--duration.iso", "P1DT12H",
+		"--duration.slice", "1w,2d12h,PT30M,-1.5h",
+		"--duration.invalid", "P1M",
+	})
+
+	value, err := x.Fetch("duration.days").ExtendedDuration()
+	require.NoError(t, err)
+	require.Equal(t, 7*24*time.Hour, value)
+
+	value, err = x.Fetch("duration.iso").ExtendedDuration()
+	require.NoError(t, err)
+	require.Equal(t, 36*time.Hour, value)
+
+	_, err = x.Fetch("duration.days").Duration()
+	require.Error(t, err)
+
+	slice, err := x.Fetch("duration.slice").DurationSlice(WithExtendedDuration())
+	require.NoError(t, err)
+	require.Equal(t, []time.Duration{168 * time.Hour, 60 * time.Hour, 30 * time.Minute, -90 * time.Minute}, slice)
+
+	_, err = x.Fetch("duration.invalid").ExtendedDuration()
+	require.Error(t, err)
+
+	for _, s := range []string{"100000000w", "P100000000W", "15250w1w", "PT2562047H48M"} {
+		_, err = ParseDuration(s)
+		require.ErrorIs(t, err, errDurationOverflow, s)
+	}
+	value, err = ParseDuration("2562047h47m16.854775807s")
+	require.NoError(t, err)
+	require.Equal(t, time.Duration(math.MaxInt64), value)
+
+	require.Equal(t, "1w", FormatDuration(168*time.Hour))
+	require.Equal(t, "1d12h", FormatDuration(36*time.Hour))
+	require.Equal(t, "1m30s", FormatDuration(90*time.Second))
+	require.Equal(t, "-2h500ms", FormatDuration(-(2*time.Hour + 500*time.Millisecond)))
+	require.Equal(t, "0s", FormatDuration(0))
+	require.Equal(t, "-15250w1d23h47m16.854775808s", FormatDuration(math.MinInt64))
+	for _, d := range []time.Duration{time.Nanosecond, 1500 * time.Millisecond, 9*day + 3*time.Minute} {
+		parsed, err := ParseDuration(FormatDuration(d))
+		require.NoError(t, err)
+		require.Equal(t, d, parsed)
+	}
+}
+
+func TestSliceSplit(t *testing.T) {
+	x := NewWithArgs([]string{"app",
+		"--split.quoted", `"a,b",c,"say ""hi""",d\,e`,
+		"--split.unterminated", `"a,b`,
+		"--split.multi", "1,2;3|4",
+		"--split.pattern", "1 , 2;  3",
+		"--split.whitespace", "  x  y\tz ",
+	})
+
+	slice, err := x.Fetch("split.quoted").StringSlice(WithQuotes[string]())
+	require.NoError(t, err)
+	require.Equal(t, []string{"a,b", "c", `say "hi"`, "d,e"}, slice)
+
+	slice, err = x.Fetch("split.quoted").StringSlice()
+	require.NoError(t, err)
+	require.Equal(t, 6, len(slice))
+
+	_, err = x.Fetch("split.unterminated").StringSlice(WithQuotes[string]())
+	require.Error(t, err)
+
+	ints, err := x.Fetch("split.multi").IntSlice(WithDelimiters[int](",", ";", "|"))
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3, 4}, ints)
+
+	ints, err = x.Fetch("split.pattern").IntSlice(WithDelimiterPattern[int](`\s*[,;]\s*`))
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, ints)
+
+	_, err = x.Fetch("split.pattern").IntSlice(WithDelimiterPattern[int](`[`))
+	require.Error(t, err)
+
+	slice, err = x.Fetch("split.whitespace").StringSlice(WithWhitespace[string]())
+	require.NoError(t, err)
+	require.Equal(t, []string{"x", "y", "z"}, slice)
+}
+
+func TestNestedSlice(t *testing.T) {
+	x := NewWithArgs([]string{"app",
+		"--nested.shards", "1,2;3,4;;5",
+		"--nested.custom", "a b|c",
+		"--nested.invalid", "1,2;x",
+		"--nested.empty",
+	})
+
+	shards, err := x.Fetch("nested.shards").IntSlices()
+	require.NoError(t, err)
+	require.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, shards)
+
+	slices, err := x.Fetch("nested.custom").StringSlices(WithDelimiter[string](" "), WithOuterDelimiter[string]("|"))
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"a", "b"}, {"c"}}, slices)
+
+	_, err = x.Fetch("nested.invalid").Int64Slices()
+	require.Error(t, err)
+
+	_, err = x.Fetch("nested.shards").IntSlices(WithMax(4))
+	require.Error(t, err)
+
+	require.Nil(t, x.Fetch("nested.empty").MustIntSlices())
+	require.Equal(t, [][]int{{7, 8}}, x.Fetch("nested.empty").MustIntSlices(WithDefault(7, 8)))
+
+	slices, err = NewV(`"a;b",c;d\;e,"f""g"`).StringSlices(WithQuotes[string]())
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"a;b", "c"}, {"d;e", `f"g`}}, slices)
+
+	_, err = NewV(`"a;b,c`).StringSlices(WithQuotes[string]())
+	require.EqualError(t, err, "invalid slice value: unterminated quote")
+}
@@ -0,0 +1,87 @@
+package argsx
+
+import (
+	"errors"
+	"fmt"
+)
+
+// enableExperimental is the built-in key accepts experimental flags
+const enableExperimental = "enable-experimental"
+
+// Visibility controls where a flag is listed and whether it's accepted
+type Visibility int
+
+const (
+	// Visible flags are listed in help, completion and docs
+	Visible Visibility = iota
+	// Hidden flags are accepted but not listed in help, completion and docs
+	Hidden
+	// Experimental flags are listed with annotation, the args and env are accepted only with --enable-experimental
+	Experimental
+)
+
+// listedFlags returns the flags not hidden, deprecated flags are excluded too when withDeprecated is false.
+// The built-in --enable-experimental is appended when any of listed flags is experimental
+func listedFlags(flags []Flag, withDeprecated bool) []Flag {
+	listed := make([]Flag, 0, len(flags)+1)
+	for _, flag := range flags {
+		if flag.Visibility == Hidden || (!withDeprecated && len(flag.Deprecated) > 0) {
+			continue
+		}
+		listed = append(listed, flag)
+	}
+	if hasExperimental(listed) {
+		listed = append(listed, Flag{Name: enableExperimental, Type: "bool", Usage: "accept experimental flags"})
+	}
+	return listed
+}
+
+// hasExperimental reports whether any of flags is experimental
+func hasExperimental(flags []Flag) bool {
+	for _, flag := range flags {
+		if flag.Visibility == Experimental {
+			return true
+		}
+	}
+	return false
+}
+
+// experimentalEnabled reports whether the experimental flags are enabled by --enable-experimental
+func (s *Snapshot) experimentalEnabled() bool {
+	value, ok := s.values[s.resolver.canonical(enableExperimental)]
+	return ok && value.MustBool()
+}
+
+// rejectExperimental removes the experimental flags specified without --enable-experimental,
+// it's an error in strict mode otherwise a warning
+func (x *Argsx) rejectExperimental(s *Snapshot) error {
+	if s.experimentalEnabled() {
+		return nil
+	}
+
+	var errs []error
+	for _, flag := range s.flags {
+		if flag.Visibility != Experimental {
+			continue
+		}
+		ck := s.resolver.canonical(flag.Name)
+		value, ok := s.values[ck]
+		if !ok {
+			continue
+		}
+
+		delete(s.values, ck)
+		errs = append(errs, x.experimental(s, fmt.Sprintf("flag `%s`", value.fullkey)))
+	}
+	return errors.Join(errs...)
+}
+
+// experimental returns the error of experimental source specified without --enable-experimental
+// in strict mode, otherwise warns and returns nil
+func (x *Argsx) experimental(s *Snapshot, source string) error {
+	if x.strict {
+		return fmt.Errorf("%s is experimental, enable it by `--%s`", source, enableExperimental)
+	}
+	s.warnf("%s is experimental and ignored, enable it by `--%s`", source, enableExperimental)
+	return nil
+}
@@ -0,0 +1,75 @@
+package argsx
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVisibility(t *testing.T) {
+	warnings := new(bytes.Buffer)
+	x := NewWithArgs([]string{"app", "--debug-dump", "--turbo"})
+	x.SetWarnWriter(warnings)
+	x.Register(
+		Flag{Name: "port", Type: "int", Usage: "listen port"},
+		Flag{Name: "debug-dump", Type: "bool", Usage: "dump internals", Visibility: Hidden},
+		Flag{Name: "turbo", Type: "bool", Usage: "turbo mode", Visibility: Experimental},
+	)
+
+	require.NoError(t, x.Parse())
+	require.True(t, x.Fetch("debug-dump").MustBool())
+	require.Equal(t, Value{}, x.Fetch("turbo"))
+	require.Equal(t, "warning: flag `--turbo` is experimental and ignored, enable it by `--enable-experimental`\n", warnings.String())
+
+	x.SetStrict(true)
+	require.EqualError(t, x.Parse(), "flag `--turbo` is experimental, enable it by `--enable-experimental`")
+
+	x.SetArgs([]string{"app", "--turbo", "--enable-experimental"})
+	require.NoError(t, x.Parse())
+	require.True(t, x.Fetch("turbo").MustBool())
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, x.WriteHelp(buf))
+	require.Equal(t, `Usage: app [flags]
+
+Flags:
+  --port int              listen port
+  --turbo                 turbo mode (experimental)
+  --enable-experimental   accept experimental flags
+`, buf.String())
+
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		buf.Reset()
+		require.NoError(t, x.WriteCompletion(buf, shell))
+		require.NotContains(t, buf.String(), "debug-dump")
+		require.Contains(t, buf.String(), "enable-experimental")
+	}
+	buf.Reset()
+	require.NoError(t, x.WriteMarkdown(buf, MarkdownHeader{}))
+	require.NotContains(t, buf.String(), "debug-dump")
+	require.Contains(t, buf.String(), "**Experimental:** requires `--enable-experimental`. turbo mode")
+	require.Contains(t, buf.String(), "| `--enable-experimental` | bool |")
+
+	buf.Reset()
+	require.NoError(t, x.WriteMan(buf, ManHeader{}))
+	require.Contains(t, buf.String(), "\\fB\\-\\-enable\\-experimental\\fR\naccept experimental flags\n")
+
+	buf.Reset()
+	x.SetArgs([]string{"app", "__complete", "--en"})
+	require.True(t, x.HandleCompletion(buf))
+	require.Equal(t, "--enable-experimental\taccept experimental flags\n", buf.String())
+
+	// env of experimental flags is gated too, the default is kept
+	t.Setenv("APP_TURBO", "true")
+	x.Register(Flag{Name: "turbo", Type: "bool", Env: "APP_TURBO", Visibility: Experimental},
+		Flag{Name: "level", Default: "1", Visibility: Experimental})
+	x.SetArgs([]string{"app"})
+	require.EqualError(t, x.Parse(), "env `$APP_TURBO` of flag `--turbo` is experimental, enable it by `--enable-experimental`")
+	require.Equal(t, Value{}, x.Fetch("turbo"))
+	require.Equal(t, "1", x.Fetch("level").MustString())
+
+	x.SetArgs([]string{"app", "--enable-experimental"})
+	require.NoError(t, x.Parse())
+	require.True(t, x.Fetch("turbo").MustBool())
+}
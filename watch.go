@@ -0,0 +1,191 @@
+package argsx
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// ChangeFunc is called when the effective value of key changed after reloading,
+// old or new is zero Value when the key is added or removed
+type ChangeFunc func(key string, old, new Value)
+
+// OnChange subscribes the changes of effective values after Reload
+//
+//	OnChange(func(key string, old, new Value) {
+//		log.Printf("%s changed from %q to %q", key, old.MustString(), new.MustString())
+//	})
+func (x *Argsx) OnChange(fn ChangeFunc) {
+	x.mux.Lock()
+	defer x.mux.Unlock()
+	x.listeners = append(x.listeners, fn)
+}
+
+// Reload parses args again with response files and flag files re-read,
+// then calls OnChange subscribers for the keys whose effective value changed
+func (x *Argsx) Reload() error {
+	x.Snapshot()
+	x.mux.Lock()
+	var old map[string]Value
+	if prev := x.snapshot.Load(); prev != nil {
+		old = prev.values
+	}
+	s := x.parse()
+	x.store(s)
+	listeners := x.listeners
+	x.mux.Unlock()
+
+	values := s.values
+	keys := make([]string, 0, len(values))
+	for key, value := range values {
+		if prev, ok := old[key]; !ok || prev.payload != value.payload {
+			keys = append(keys, key)
+		}
+	}
+	for key := range old {
+		if _, ok := values[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, listener := range listeners {
+			listener(key, old[key], values[key])
+		}
+	}
+	return s.err
+}
+
+// Watch polls the response files and flag files every interval and reloads when
+// any of them is modified, call stop to stop watching. The interval must be positive
+//
+//	stop, err := Watch(time.Second)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer stop()
+func (x *Argsx) Watch(interval time.Duration) (stop func(), err error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid watch interval: %s, must be positive", interval)
+	}
+
+	done := make(chan struct{})
+	states := x.fileStates()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if current := x.fileStates(); !sameStates(states, current) {
+					_ = x.Reload()
+					states = x.fileStates()
+				}
+			}
+		}
+	}()
+	return closer(done), nil
+}
+
+// ReloadOnSignal reloads when any of signals received, default is SIGHUP,
+// call stop to stop listening. On platforms without SIGHUP like js the signals
+// must be specified, nothing is listened without them
+//
+//	stop := ReloadOnSignal()
+//	defer stop()
+func (x *Argsx) ReloadOnSignal(signals ...os.Signal) (stop func()) {
+	if len(signals) == 0 {
+		signals = reloadSignals
+	}
+	done := make(chan struct{})
+	if len(signals) == 0 {
+		return closer(done)
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, signals...)
+	go func() {
+		defer signal.Stop(ch)
+		for {
+			select {
+			case <-done:
+				return
+			case <-ch:
+				_ = x.Reload()
+			}
+		}
+	}()
+	return closer(done)
+}
+
+// fileState is the modification state of a watched file
+type fileState struct {
+	modTime time.Time
+	size    int64
+	exists  bool
+}
+
+// fileStates returns the states of files read in the last parsing
+func (x *Argsx) fileStates() map[string]fileState {
+	files := x.Snapshot().files
+	states := make(map[string]fileState, len(files))
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			states[file] = fileState{info.ModTime(), info.Size(), true}
+		} else {
+			states[file] = fileState{}
+		}
+	}
+	return states
+}
+
+// sameStates reports whether the files are not modified
+func sameStates(a, b map[string]fileState) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for file, state := range a {
+		if other, ok := b[file]; !ok || !other.modTime.Equal(state.modTime) ||
+			other.size != state.size || other.exists != state.exists {
+			return false
+		}
+	}
+	return true
+}
+
+// closer returns a function closes done once
+func closer(done chan struct{}) func() {
+	var closed uint32
+	return func() {
+		if atomic.CompareAndSwapUint32(&closed, 0, 1) {
+			close(done)
+		}
+	}
+}
+
+// OnChange subscribes the changes of effective values of the default instance
+func OnChange(fn ChangeFunc) {
+	dx.OnChange(fn)
+}
+
+// Reload parses args of the default instance again
+func Reload() error {
+	return dx.Reload()
+}
+
+// Watch polls the files of the default instance and reloads when modified
+func Watch(interval time.Duration) (stop func(), err error) {
+	return dx.Watch(interval)
+}
+
+// ReloadOnSignal reloads the default instance when any of signals received
+func ReloadOnSignal(signals ...os.Signal) (stop func()) {
+	return dx.ReloadOnSignal(signals...)
+}
@@ -0,0 +1,64 @@
+//go:build !windows && !js
+
+package argsx
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type change struct {
+	key      string
+	old, new string
+}
+
+func TestWatch(t *testing.T) {
+	flagfile := filepath.Join(t.TempDir(), "flags.txt")
+	require.NoError(t, os.WriteFile(flagfile, []byte("--level=info\n--replicas=3\n"), 0o644))
+
+	x := NewWithArgs([]string{"app", "--flagfile", flagfile, "--name", "app"})
+	require.Equal(t, "info", x.Fetch("level").MustString())
+
+	changes := make(chan change, 10)
+	x.OnChange(func(key string, old, new Value) {
+		changes <- change{key, old.payload, new.payload}
+	})
+
+	_, err := x.Watch(0)
+	require.EqualError(t, err, "invalid watch interval: 0s, must be positive")
+
+	stop, err := x.Watch(10 * time.Millisecond)
+	require.NoError(t, err)
+	defer stop()
+
+	require.NoError(t, os.WriteFile(flagfile, []byte("--level=debug\n--replicas=3\n--region=us\n"), 0o644))
+	require.Equal(t, change{"level", "info", "debug"}, receive(t, changes))
+	require.Equal(t, change{"region", "", "us"}, receive(t, changes))
+	require.Equal(t, "debug", x.Fetch("level").MustString())
+	stop()
+
+	stop = x.ReloadOnSignal()
+	defer stop()
+	require.NoError(t, os.WriteFile(flagfile, []byte("--level=warn\n"), 0o644))
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+	require.Equal(t, change{"level", "debug", "warn"}, receive(t, changes))
+	require.Equal(t, change{"region", "us", ""}, receive(t, changes))
+	require.Equal(t, change{"replicas", "3", ""}, receive(t, changes))
+}
+
+// receive returns the next change or fails the test after timeout
+func receive(t *testing.T, changes <-chan change) change {
+	t.Helper()
+	select {
+	case c := <-changes:
+		return c
+	case <-time.After(time.Second * 5):
+		t.Fatal("no change received")
+		return change{}
+	}
+}